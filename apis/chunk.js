@@ -0,0 +1,9 @@
+{{define "chunk" -}}
+function () {
+{{helpers .}}
+  return {
+    c: {{.GetSource}},
+    s: {{with style .}}{{.}}{{else}}""{{end}}
+  };
+}
+{{- end}}
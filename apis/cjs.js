@@ -0,0 +1,6 @@
+{{template "runtime" .}}
+
+exports.Wb = Wb;
+{{range $i, $o := .Scripts}}
+exports["{{js $o.GetName}}"] = Wb("{{js $o.GetName}}");
+{{- end}}
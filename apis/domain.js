@@ -0,0 +1,35 @@
+{{define "domain" -}}
+  // domain reports whether the location l is one of the domains ds, the
+  // domains given to Secure as parsed by the wooblizer
+  function domain(ds, l) {
+    var host = l.hostname.replace(/\.$/, ''), ip = address(host), d, i, j;
+    var port = +(l.port || (l.protocol == 'https:' ? 443 : 80));
+    for (i = 0; i < ds.length; i++) {
+      d = ds[i];
+      if (d.p && d.p != port) continue;
+      if (d.ip) {
+        if (!ip || ip.length != d.ip.length) continue;
+        for (j = 0; j < (d.b || 0) && !((ip[j >> 3] ^ d.ip[j >> 3]) & (0x80 >> (j & 7))); j++) {}
+        if (j == (d.b || 0)) return true;
+      } else if (d.s ? host.slice(-d.h.length - 1) == '.' + d.h : host == d.h) {
+        return true;
+      }
+    }
+    return false;
+  }
+
+  // address returns the bytes of an IP address host name, as browsers
+  // serialize it, or undefined
+  function address(host) {
+    var m = /^(\d+)\.(\d+)\.(\d+)\.(\d+)$/.exec(host), g, i, b = [];
+    if (m) return [+m[1], +m[2], +m[3], +m[4]];
+    if (host.charAt(0) != '[') return;
+    m = host.slice(1, -1).split('::');
+    g = m[0] ? m[0].split(':') : [];
+    var tail = m[1] ? m[1].split(':') : [];
+    while (g.length + tail.length < 8) g.push('0');
+    g = g.concat(tail);
+    for (i = 0; i < 8; i++) b.push(parseInt(g[i], 16) >> 8, parseInt(g[i], 16) & 255);
+    return b;
+  }
+{{- end}}
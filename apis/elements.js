@@ -0,0 +1,51 @@
+{{template "runtime" .}}
+
+(function () {
+  // element returns the custom element class of a creation, attrs maps the
+  // observed attributes to the creation parameters
+  function element(id, attrs) {
+    return class extends HTMLElement {
+      static get observedAttributes() {
+        return Object.keys(attrs);
+      }
+
+      // The creation attaches the shadow root to the element
+      connectedCallback() {
+        if (this.wooble) return;
+        var p = {};
+        for (var a in attrs) {
+          if (this.hasAttribute(a)) p[attrs[a]] = this.getAttribute(a);
+        }
+        var el = this;
+        this.wooble = Wb(id).init(this, p).then(function (cs) {
+          el.creation = cs[0];
+          return cs[0];
+        });
+      }
+
+      attributeChangedCallback(name, oldValue, newValue) {
+        var c = this.creation;
+        if (c && typeof c.attributeChangedCallback == 'function') c.attributeChangedCallback(attrs[name], oldValue, newValue);
+      }
+    };
+  }
+
+  function define() {
+    {{- range $i, $o := .Scripts}}
+    if (Wb("{{js $o.GetName}}") && !customElements.get("{{tag $o.GetName}}")) customElements.define("{{tag $o.GetName}}", element("{{js $o.GetName}}", {
+      {{- range $j, $p := $o.GetParams}}{{if $j}},{{end}}
+      "{{attr $p.Field}}": "{{$p.Field}}"
+      {{- end}}
+    }));
+    {{- end}}
+  }
+
+  if (window.customElements) {
+    define();
+  } else {
+    // Browsers custom elements support with polyfill
+    Wb.__polyfill().then(define, function (e) {
+      console.log(e.message);
+    });
+  }
+})();
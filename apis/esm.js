@@ -0,0 +1,10 @@
+{{template "runtime" .}}
+{{- if and .Chunks (not .Base)}}
+Wb.base = new URL('.', import.meta.url).href;
+{{- end}}
+
+export default Wb;
+export { Wb };
+{{range $i, $o := .Scripts}}
+export var {{ident $o.GetName}} = Wb("{{js $o.GetName}}");
+{{- end}}
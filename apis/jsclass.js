@@ -0,0 +1 @@
+{{template "wb.js" .}}
@@ -0,0 +1,185 @@
+{{define "license" -}}
+  // license verifies the license token of the library with its key, it
+  // returns '' if host is licensed at now (seconds), or the reason it is not
+  function license(token, key, alg, host, now) {
+    var parts = token.split('.'), h, c, sig, i;
+    try {
+      if (parts.length != 3) throw parts;
+      h = JSON.parse(text(bytes64(parts[0])));
+      c = JSON.parse(text(bytes64(parts[1])));
+      sig = bytes64(parts[2]);
+    } catch (e) {
+      return 'Malformed license';
+    }
+    if (!h || !c || typeof c.exp != 'number' || !Array.isArray(c.domains)) return 'Malformed license';
+    for (i = 0; i < c.domains.length; i++) {
+      if (typeof c.domains[i] != 'string') return 'Malformed license';
+    }
+    if (h.alg != alg) return 'Unknown license key';
+
+    var msg = ascii(parts[0] + '.' + parts[1]);
+    if (!(alg == 'HS256' ? same(hmac(bytes64(key), msg), sig) : ed25519(bytes64(key), msg, sig))) return 'Invalid license signature';
+    if (now >= c.exp) return 'License expired';
+    for (i = 0; i < c.domains.length; i++) {
+      if (c.domains[i].toLowerCase() == host.toLowerCase()) return '';
+    }
+    return 'Domain not licensed';
+  }
+
+  // bytes64 decodes base64url without padding
+  function bytes64(s) {
+    if (!/^[A-Za-z0-9_-]*$/.test(s) || s.length % 4 == 1) throw s;
+    return ascii(atob(s.replace(/-/g, '+').replace(/_/g, '/')));
+  }
+
+  function ascii(s) {
+    var b = [];
+    for (var i = 0; i < s.length; i++) b.push(s.charCodeAt(i));
+    return b;
+  }
+
+  function text(b) {
+    return new TextDecoder().decode(new Uint8Array(b));
+  }
+
+  function same(a, b) {
+    var d = a.length ^ b.length;
+    for (var i = 0; i < a.length && i < b.length; i++) d |= a[i] ^ b[i];
+    return d == 0;
+  }
+
+  function big(n) {
+    return BigInt(n);
+  }
+
+  // root returns the integer e-th root of v, e is 2 or 3
+  function root(v, e) {
+    var E = big(e), x = big(1) << big(Math.ceil(v.toString(2).length / e)), y;
+    for (;;) {
+      y = ((E - big(1)) * x + v / (e == 2 ? x : x * x)) / E;
+      if (y >= x) return x;
+      x = y;
+    }
+  }
+
+  // sha2 returns the SHA-256 digest of bytes if w is 32, SHA-512 if w is 64.
+  // The constants are the fractional parts of the roots of the first primes.
+  function sha2(msg, w) {
+    var W = big(w), M = (big(1) << W) - big(1), n = w == 32 ? 64 : 80, wb = w / 8;
+    var r = w == 32 ? [2, 13, 22, 6, 11, 25, 7, 18, 3, 17, 19, 10] : [28, 34, 39, 14, 18, 41, 1, 8, 7, 19, 61, 6];
+    var primes = [], k = [], h = [], i, j, x;
+    for (x = 2; primes.length < n; x++) {
+      for (j = 0; j < primes.length && x % primes[j]; j++) {}
+      if (j == primes.length) primes.push(x);
+    }
+    for (i = 0; i < n; i++) {
+      k.push(root(big(primes[i]) << big(3 * w), 3) & M);
+      if (i < 8) h.push(root(big(primes[i]) << big(2 * w), 2) & M);
+    }
+    function rot(x, a) {
+      return ((x >> big(a)) | (x << (W - big(a)))) & M;
+    }
+    function sum(x, o, shift) {
+      return rot(x, r[o]) ^ rot(x, r[o + 1]) ^ (shift ? x >> big(r[o + 2]) : rot(x, r[o + 2]));
+    }
+
+    var b = msg.slice(), len = big(msg.length * 8);
+    b.push(0x80);
+    while ((b.length + 2 * wb) % (16 * wb)) b.push(0);
+    for (i = 2 * wb - 1; i >= 0; i--) b.push(Number((len >> big(8 * i)) & big(255)));
+
+    for (var o = 0; o < b.length; o += 16 * wb) {
+      var v = [], a = h.slice(), t1, t2;
+      for (i = 0; i < n; i++) {
+        if (i < 16) {
+          for (x = big(0), j = 0; j < wb; j++) x = (x << big(8)) | big(b[o + i * wb + j]);
+          v.push(x);
+        } else {
+          v.push((sum(v[i - 15], 6, true) + v[i - 7] + sum(v[i - 2], 9, true) + v[i - 16]) & M);
+        }
+        t1 = (a[7] + sum(a[4], 3) + ((a[4] & a[5]) ^ (~a[4] & a[6])) + k[i] + v[i]) & M;
+        t2 = (sum(a[0], 0) + ((a[0] & a[1]) ^ (a[0] & a[2]) ^ (a[1] & a[2]))) & M;
+        a = [(t1 + t2) & M, a[0], a[1], a[2], (a[3] + t1) & M, a[4], a[5], a[6]];
+      }
+      for (i = 0; i < 8; i++) h[i] = (h[i] + a[i]) & M;
+    }
+
+    var out = [];
+    for (i = 0; i < 8; i++) {
+      for (j = wb - 1; j >= 0; j--) out.push(Number((h[i] >> big(8 * j)) & big(255)));
+    }
+    return out;
+  }
+
+  function hmac(key, msg) {
+    var i, ip = [], op = [];
+    if (key.length > 64) key = sha2(key, 32);
+    for (i = 0; i < 64; i++) {
+      ip.push((key[i] || 0) ^ 0x36);
+      op.push((key[i] || 0) ^ 0x5c);
+    }
+    return sha2(op.concat(sha2(ip.concat(msg), 32)), 32);
+  }
+
+  // ed25519 verifies an Ed25519 signature of msg by the public key pub, as
+  // RFC 8032 does
+  function ed25519(pub, msg, sig) {
+    var P = (big(1) << big(255)) - big(19), L = (big(1) << big(252)) + big('27742317777372353535851937790883648493');
+    var zero = big(0), one = big(1), two = big(2);
+    function md(a) {
+      a %= P;
+      return a < zero ? a + P : a;
+    }
+    function pow(b, e) {
+      for (var r = one; e > zero; e >>= one, b = b * b % P) {
+        if (e & one) r = r * b % P;
+      }
+      return r;
+    }
+    function le(b) {
+      for (var x = zero, i = b.length - 1; i >= 0; i--) x = (x << big(8)) | big(b[i]);
+      return x;
+    }
+    var d = md(big(-121665) * pow(big(121666), P - two)), I = pow(two, (P - one) / big(4));
+
+    // Points are in extended coordinates X, Y, Z, T
+    function add(p, q) {
+      var a = md((p[1] - p[0]) * (q[1] - q[0])), b = md((p[1] + p[0]) * (q[1] + q[0]));
+      var c = md(p[3] * two * d % P * q[3]), e = md(p[2] * two * q[2]);
+      var f = md(b - a), g = md(e - c), h = md(e + c), i = md(b + a);
+      return [f * g % P, h * i % P, g * h % P, f * i % P];
+    }
+    function mul(p, s) {
+      for (var q = [zero, one, one, zero]; s > zero; s >>= one, p = add(p, p)) {
+        if (s & one) q = add(q, p);
+      }
+      return q;
+    }
+    function decode(b) {
+      var y = le(b) & ((one << big(255)) - one), sign = big(b[31] >> 7);
+      if (y >= P) return null;
+      var u = md(y * y - one), v = md(d * y * y + one);
+      var x = md(u * pow(v, big(3)) % P * pow(u * pow(v, big(7)) % P, (P - big(5)) / big(8)));
+      if (md(v * x * x) != u) {
+        if (md(v * x * x) != md(-u)) return null;
+        x = x * I % P;
+      }
+      if (x == zero && sign == one) return null;
+      if ((x & one) != sign) x = P - x;
+      return [x, y, one, x * y % P];
+    }
+    function encode(p) {
+      var z = pow(p[2], P - two), x = p[0] * z % P, y = p[1] * z % P, b = [];
+      for (var i = 0; i < 32; i++, y >>= big(8)) b.push(Number(y & big(255)));
+      b[31] |= Number(x & one) << 7;
+      return b;
+    }
+
+    if (pub.length != 32 || sig.length != 64) return false;
+    var s = le(sig.slice(32)), A = decode(pub), B = decode(encode([zero, md(big(4) * pow(big(5), P - two)), one, zero]));
+    if (!A || s >= L) return false;
+    var k = le(sha2(sig.slice(0, 32).concat(pub, msg), 64)) % L;
+    A = [md(-A[0]), A[1], A[2], md(-A[3])];
+    return same(encode(add(mul(B, s), mul(A, k))), sig.slice(0, 32));
+  }
+{{- end}}
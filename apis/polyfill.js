@@ -0,0 +1,34 @@
+{{define "polyfill" -}}
+// __polyfill polyfills Shadow DOM and custom elements once, the promise is
+// rejected if the polyfill fails to load, it is loaded again on the next call
+Wb.__polyfill = function () {
+  if (Wb.__pf) return Wb.__pf;
+  return Wb.__pf = new Promise(function (r, e) {
+  {{- with .Polyfill}}
+  {{- if .Disabled}}
+    e(new Error("Wooble error : Shadow DOM not supported, the polyfill is disabled"));
+  {{- else if .Inline}}
+    (function () {
+{{printf "%s" .Inline}}
+    }).call(window);
+    r();
+  {{- else}}
+    var s = document.createElement('script');
+    s.src = "{{js .Src}}";
+    {{- with .Integrity}}
+    s.integrity = "{{js .}}";
+    s.crossOrigin = 'anonymous';
+    {{- end}}
+    s.onload = function () {
+      r();
+    };
+    s.onerror = function () {
+      delete Wb.__pf;
+      e(new Error("Wooble error : polyfill " + s.src + " not loaded"));
+    };
+    document.head.appendChild(s);
+  {{- end}}
+  {{- end}}
+  });
+};
+{{- end}}
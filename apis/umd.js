@@ -0,0 +1,17 @@
+(function (root, factory) {
+  if (typeof define === 'function' && define.amd) {
+    define([], factory);
+  } else if (typeof module === 'object' && module.exports) {
+    module.exports = factory();
+  } else {
+    root.Wb = factory().Wb;
+  }
+}(typeof self !== 'undefined' ? self : this, function () {
+{{template "runtime" .}}
+
+  return {
+    Wb: Wb{{range $i, $o := .Scripts}},
+    "{{js $o.GetName}}": Wb("{{js $o.GetName}}")
+    {{- end}}
+  };
+}));
@@ -0,0 +1,234 @@
+{{if not .Chunks}}{{helpers .Scripts}}{{end -}}
+function Wb(id) {
+	{{if .DomainsSec}}
+	if (!domain({{domains .DomainsSec .AllowLocalhost}}, window.location)) {
+		console.log("Wooble error : domain restricted");
+		return;
+	}
+	{{end}}
+
+	{{with .License}}
+	// The license is verified once
+	if (!Wb.hasOwnProperty('__lic')) Wb.__lic = license("{{js .Token}}", "{{.KeyData}}", "{{.Alg}}", window.location.hostname, Date.now() / 1000);
+	if (Wb.__lic) {
+		console.log("Wooble error : " + Wb.__lic);
+		return;
+	}
+	{{end}}
+
+	if(!(this instanceof Wb)) {
+  	return new Wb(id);
+  }
+
+  var cs = {
+		{{$lenScripts := len .Scripts}}
+  	{{range $i, $o := .Scripts}}
+			"{{js $o.GetName}}":{{with index $.Chunks $o.GetName}}["{{.File}}","{{.Integrity}}"]{{else}}{{$o.GetSource}}{{end}},
+			"__{{js $o.GetName}}":{
+			{{$lenParams := len $o.GetParams}}
+			{{range $i, $p := $o.GetParams}}
+				"{{$p.Field}}":{{$p.Literal}}{{if ne (plus1 $i) $lenParams}},{{end}}
+			{{end}}
+			}{{if ne (plus1 $i) $lenScripts}},{{end}}
+		{{end}}
+  }
+
+  // Schemas of the typed parameters, they validate the values given to init
+  var ps = {
+  	{{range $i, $o := .Scripts}}{{with schema $o}}
+			"{{js $o.GetName}}":{{.}},
+		{{end}}{{end}}
+  }
+
+  // Style sheets of the creations, created on their first init
+  var ss = {
+  	{{range $i, $o := .Scripts}}{{if not (index $.Chunks $o.GetName)}}{{with style $o}}
+			"{{js $o.GetName}}":{{.}},
+		{{end}}{{end}}{{end}}
+  }
+
+  var c = cs[id];
+  if(typeof c == 'undefined') {
+  	console.log("Wooble error : creation", id, "not found");
+    return undefined;
+  }
+
+  // tar is a selector or an element
+  this.init = function (tar, p) {
+    if(typeof tar == 'string' ? document.querySelector(tar) == null : !tar) {
+    	console.log("Wooble error : Element", tar, "not found in the document");
+      return;
+    }
+
+		// Parameters are copied, the defaults are shared by all the instances.
+		// A typed parameter keeps its default if the value is invalid.
+		var _ = {}, d = cs['__'+id], sc = ps[id] || {};
+		for (var prop in d) _[prop] = d[prop];
+		if (p) {
+			for (var prop in p) {
+				if (!_.hasOwnProperty(prop)) continue;
+				if (!sc.hasOwnProperty(prop)) {
+					_[prop] = p[prop];
+				} else if (p[prop] != null) {
+					var v = coerce(sc[prop].type, p[prop]), err = invalid(sc[prop], v);
+					if (err) console.log("Wooble error : parameter", prop, "of", id + ":", err);
+					else _[prop] = v;
+				}
+			}
+		}
+		for (var prop in sc) {
+			if (sc[prop].required && _[prop] == null) {
+				console.log("Wooble error : parameter", prop, "of", id, "is required");
+				return;
+			}
+		}
+		p = _;
+
+		{{- if .Chunks}}
+
+		// The chunk of the creation is fetched on its first init
+		return load(id, c).then(function (ch) {
+			if (ch.s) ss[id] = ch.s;
+			return create(ch.c);
+		});
+		{{- else}}
+
+		return create(c);
+		{{- end}}
+
+		function create(c) {
+			if (ss.hasOwnProperty(id)) {
+				// The sheet is parsed once for all the instances, the constructor
+				// falls back on a style element without it
+				var sh = Wb.__ss || (Wb.__ss = {});
+				if (!sh.hasOwnProperty(id)) sh[id] = sheet(ss[id]);
+				c.__ss = sh[id];
+				c.__css = ss[id];
+			}
+
+			var t = this;
+			var _cs = [];
+			function start() {
+				var __ds = typeof tar == 'string' ? document.querySelectorAll(tar) : [tar];
+				for (var i = 0; i < __ds.length; i++) _cs.push(new c(__ds[i], p));
+				return _cs;
+			}
+			// Browsers shadow dom support with polyfill
+			if (!document.head.attachShadow) return Wb.__polyfill().then(start);
+			return new Promise(function (r) {
+				r(start());
+			});
+		}
+  }
+
+  // coerce converts a string, such as an attribute value, to the type of a
+  // parameter
+  function coerce(t, v) {
+  	if (typeof v != 'string') return v;
+  	if (t == 'number' && v.trim() != '') return Number(v);
+  	if (t == 'boolean' && (v == '' || v == 'true' || v == 'false')) return v != 'false';
+  	if (t == 'array' || t == 'object') {
+  		try {
+  			return JSON.parse(v);
+  		} catch (e) {}
+  	}
+  	return v;
+  }
+
+  // invalid returns what is wrong with the value of a parameter, or '' if it
+  // is valid against its schema s
+  function invalid(s, v) {
+  	var t = s.type, n, i, err;
+  	switch (t) {
+  	case 'number':
+  		if (typeof v != 'number' || !isFinite(v)) return 'expected a number';
+  		n = v;
+  		break;
+  	case 'boolean':
+  		if (typeof v != 'boolean') return 'expected a boolean';
+  		break;
+  	case 'object':
+  		if (v === null || typeof v != 'object' || Array.isArray(v)) return 'expected an object';
+  		break;
+  	case 'array':
+  		if (!Array.isArray(v)) return 'expected an array';
+  		for (i = 0; s.items && i < v.length; i++) {
+  			if ((err = invalid({type: s.items}, v[i]))) return 'element ' + i + ': ' + err;
+  		}
+  		n = v.length;
+  		break;
+  	case 'enum':
+  		for (i = 0; i < s.enum.length; i++) {
+  			if (s.enum[i] === v) return '';
+  		}
+  		return 'expected one of ' + JSON.stringify(s.enum).slice(1, -1);
+  	default:
+  		if (typeof v != 'string') return 'expected a string';
+  		if (t == 'color' && !/^(#([0-9a-f]{3,4}|[0-9a-f]{6}|[0-9a-f]{8})|(rgb|hsl)a?\([0-9a-z .,%\/+-]*\)|[a-z]+)$/i.test(v)) return 'expected a color';
+  		if (t == 'url') {
+  			var m = /^([a-z][a-z0-9+.-]*):/i.exec(v.replace(/[\t\n\r]/g, '').replace(/^[\x00-\x20]+/, ''));
+  			if (m && !/^https?$/i.test(m[1])) return 'expected a relative, http or https URL';
+  		}
+  		if (s.pattern && !new RegExp('^(?:' + s.pattern + ')$').test(v)) return 'expected to match ' + s.pattern;
+  		n = v.length;
+  	}
+  	var b = t == 'number' ? '' : 'a length of ';
+  	if (s.min != null && n < s.min) return 'expected ' + b + 'at least ' + s.min;
+  	if (s.max != null && n > s.max) return 'expected ' + b + 'at most ' + s.max;
+  	return '';
+  }
+
+  // sheet returns a constructable style sheet, or null if they are not
+  // supported
+  function sheet(css) {
+  	if (typeof CSSStyleSheet != 'function' || !('adoptedStyleSheets' in document)) return null;
+  	try {
+  		var s = new CSSStyleSheet();
+  		s.replaceSync(css);
+  		return s;
+  	} catch (e) {
+  		return null;
+  	}
+  }
+
+  {{- if .Chunks}}
+
+  // load fetches the chunk of a creation once, ch is its file and integrity.
+  // The chunk registers itself in __wbChunks under its file name.
+  function load(id, ch) {
+  	var ls = Wb.__ch || (Wb.__ch = {});
+  	if (!ls.hasOwnProperty(id)) ls[id] = new Promise(function (r, e) {
+  		var s = document.createElement('script');
+  		s.src = Wb.base + ch[0];
+  		s.integrity = ch[1];
+  		s.crossOrigin = 'anonymous';
+  		s.onload = function () {
+  			var cs = window.__wbChunks || {}, f = cs[ch[0]];
+  			delete cs[ch[0]];
+  			if (typeof f == 'function') return r(f());
+  			delete ls[id];
+  			e(new Error("Wooble error : chunk " + ch[0] + " of " + id + " is invalid"));
+  		};
+  		s.onerror = function () {
+  			// A failed chunk is fetched again on the next init
+  			delete ls[id];
+  			e(new Error("Wooble error : chunk " + ch[0] + " of " + id + " not loaded"));
+  		};
+  		document.head.appendChild(s);
+  	});
+  	return ls[id];
+  }
+  {{- end}}
+
+  {{if .DomainsSec}}{{template "domain"}}{{end}}
+  {{if .License}}{{template "license" .}}{{end}}
+
+  return this;
+}
+
+{{template "polyfill" .}}
+{{- with .Chunks}}
+
+// Chunks are fetched from Wb.base, the directory of the loader by default
+Wb.base = {{if $.Base}}"{{js $.Base}}"{{else}}document.currentScript ? document.currentScript.src.replace(/[^\/]*$/, '') : ''{{end}};
+{{- end}}
@@ -1,6 +1,16 @@
 // Code generated by go-bindata.
 // sources:
+// apis/chunk.js
+// apis/cjs.js
+// apis/domain.js
+// apis/elements.js
+// apis/esm.js
 // apis/js2015.js
+// apis/jsclass.js
+// apis/license.js
+// apis/polyfill.js
+// apis/umd.js
+// apis/wb.js
 // DO NOT EDIT!
 
 package wbzr
@@ -68,7 +78,107 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _apisJs2015Js = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\x4d\x6f\xdb\x38\x10\x3d\xdb\xbf\x62\x6a\x04\xb5\x84\x3a\x72\x03\xec\x29\x5a\x1d\xda\xb4\xbb\xe8\x65\x1b\x20\xc5\xf6\x60\x18\x02\x43\x8e\x23\xa6\x34\xa9\x25\xa9\x38\x86\xab\xff\xbe\x18\xea\x3b\x4d\x8a\x2e\xb0\x27\x89\xe2\x9b\x99\x37\x33\x8f\x43\x3d\x30\x0b\xb9\x53\x92\xa3\xf8\x62\xde\x59\xcb\x8e\x90\xc1\xae\xd2\xdc\x4b\xa3\x21\x8a\xe1\x34\xac\x02\xec\x93\x47\xcb\xbc\xb1\x11\xb3\x76\x05\x92\x00\xc1\x07\xb3\x16\x32\xd8\x6c\xd3\x66\xa9\x21\x03\x6f\x2b\x6c\x97\x82\xbc\x32\xe5\xba\x35\x42\x06\x95\x16\xb8\x93\x1a\x45\x0a\xde\x1e\x29\x90\xb1\x10\x85\x6d\x09\x19\x30\x6b\x37\x37\xc7\xfd\xad\x51\x89\x6c\x63\x6e\xa3\x78\x05\xb9\x4b\xe1\x55\x14\x02\x44\xb9\x83\x0c\x72\x99\x68\x7c\xf4\x51\x1c\x27\xc2\x68\x8c\xd3\x21\x3a\xb1\x23\x66\x49\x59\xb9\x22\xca\x5d\xf2\xc0\x54\x45\x08\xb9\x83\x48\xc2\xeb\xd7\xcd\xae\x42\x7d\xe7\x0b\xc8\xb2\x8c\x12\xba\xb5\xc8\xbe\xa5\x50\x43\x0d\x9c\x79\x5e\x40\x84\xd6\x06\x4f\xa2\x4f\x2a\x24\x80\xd6\x12\x6c\x27\x35\x53\x8a\x12\x68\xd2\x20\xdf\xaf\x72\x1d\xbc\xcb\xcd\xc2\xa2\xaf\xac\x5e\x6c\xe3\xc9\x2a\x8a\xa7\xa6\x64\x94\x8b\x18\x7c\x61\xcd\x01\x72\x6c\xe2\x37\xe8\x40\x32\x1d\x96\x43\x7b\x86\x1e\x90\x7d\x68\x5f\x22\x5d\x78\xd2\x5e\x4c\x3b\xad\x51\xeb\x02\x95\xc3\x00\x7e\x52\x5a\x90\x1a\x3e\xdf\xde\x23\xf7\x4f\x0d\x9f\xed\x7a\xef\xea\xd4\x32\xd6\x78\x80\x2f\xc7\x12\x3f\x5a\x6b\x6c\xb4\xf8\xa4\x1f\x98\x92\x02\x98\xf7\xb8\x2f\x3d\x78\x03\x02\x9d\xb7\x15\xf7\x95\x45\xd0\x46\x9f\x87\xc8\xb7\x0a\x41\x6a\xe7\x99\xe6\xb8\x08\x5e\xeb\x14\xea\x28\x4e\xe7\xf3\x20\x04\x6e\x91\x79\xbc\x52\xcc\xb9\x97\x75\xd9\xc8\xe8\xda\x9a\x12\xad\x97\xe8\x22\xcf\xec\x1d\xfa\x15\x94\xd6\x94\x2e\x1e\x2b\x8b\x84\xf5\x36\x05\x09\xbf\x37\x9b\x6d\xeb\x53\x90\x6f\xde\x74\x52\x16\xe8\xb8\x95\x25\x15\x25\x6b\x50\x1b\xb9\x4d\x47\x9f\x13\xd4\xd5\xbe\x25\x9f\xbd\xf0\xfd\xfb\xf7\x4e\xef\xa3\x7d\x6e\xf4\x4e\xde\x55\x9d\x65\x23\x25\x6a\xc6\x22\xe8\x72\x41\x4d\x18\xe0\xf1\xd8\xf4\x60\xa5\x9f\x98\x35\xcd\x4a\x26\xb9\x1f\xfb\xcc\x47\x96\xdf\xf0\x38\x5e\xc7\x13\x65\x0d\x15\xbd\x32\xba\xe9\x8f\xb1\xa1\x70\xde\x90\x53\xb7\x02\xe7\x99\x97\xfc\xba\x2b\x25\xd1\x1d\xb6\xe3\x1f\x8b\x3f\x72\x94\x04\xa0\x3f\x96\x38\x76\xd9\x1e\xbf\x89\xdf\x9f\x79\x99\x52\x48\x3b\xea\x23\x44\x3a\xa8\xa6\x4f\x28\xe7\x24\x9a\x2b\xa6\xd4\x55\x81\xfc\x5b\xd4\xa9\x6c\x35\x36\xec\x12\x7a\xd5\x6f\xf7\x6a\x34\xbb\x09\x30\x7e\x49\xe9\x57\x4c\x6b\xe3\x81\x33\xa5\x80\x41\x08\x0a\xcc\x01\xeb\x4b\xdb\xca\x7a\x44\xed\xeb\x6d\x24\x45\x0c\xa7\xf9\xec\x74\x92\x3b\x48\x3e\x98\x3d\x93\xda\xdd\x20\xaf\x6b\xfa\x76\xa6\x50\x7f\x30\x7b\x07\x97\x19\x28\xd4\x4f\x01\xa4\x51\x56\xd0\xb0\x3d\x9d\x2c\xd3\x77\x08\x67\x72\x05\x67\x86\xe0\x13\xe8\xe2\x74\x3a\x33\xe1\x21\x77\xa0\x11\xa2\x52\x55\xee\x02\xce\x64\x0c\x5d\x88\xba\x5e\x9d\x4e\xa8\x45\x5d\xb7\x8f\x6d\x3a\x87\x70\x0a\x1e\x1f\x69\x06\x17\x89\xd4\x02\x1f\x3f\xef\xa2\x83\xd4\xc2\x1c\x12\x65\x38\xa3\x24\x92\xc2\x38\xaf\xd9\x1e\x63\x32\x90\xbb\xe8\x57\xa0\x90\x65\x70\x7e\x41\x99\x03\xcc\xb8\xd1\xce\x28\x4c\x94\xb9\x8b\x16\x5f\x8d\x21\x71\x23\xd5\x14\x2e\x41\x84\x2c\xc0\xd2\xc8\x90\xdc\xa3\x58\x84\x30\xd0\xf6\x9e\xde\x43\xa5\x02\xe5\xf9\x7c\x26\xbb\xa0\x61\x86\xfb\x42\xba\x36\x48\xab\x15\xea\x59\x53\xf5\xc6\xb4\xcd\x91\xd3\x50\x39\xcd\x67\x6d\xcd\x6f\xc2\x11\x19\xca\xde\xae\xeb\x9a\x3c\x3d\x53\xeb\x61\x7f\x36\x9b\x85\x62\x27\x7f\xa2\xff\x8b\xed\xb1\xae\x17\x97\xdd\xfa\xc6\x54\x96\x63\x5d\xaf\x02\x2a\xcf\x7f\xc0\xd1\xf7\x86\xc0\x35\xb3\x6c\x68\xfb\x99\x49\x9a\x0f\x4d\x80\x09\x81\x92\x40\x4f\x00\x81\x42\x99\xfc\x21\x51\x89\x96\x40\x99\xfc\x4d\x83\x85\xba\xfb\xac\x04\x3a\xf3\x4e\x04\x4d\x9c\xfe\xf5\x25\xb3\x3e\xf1\x91\x5d\xf7\x36\x2e\x2f\x64\xc0\xdd\x46\x8a\x6d\xab\x11\x9a\x04\x66\x47\xdf\x33\x58\xf6\xd7\xff\xf2\x17\x04\x11\xee\x01\x3a\x4c\x2b\x90\x62\x05\x0b\x3a\x72\x3b\x53\xe9\x27\xc2\x18\xfd\x53\x74\x44\x48\x0c\x89\xd4\xd2\x4f\xee\x0f\xcf\x68\xca\x35\x81\x03\x35\x61\x78\xb5\x47\xed\x93\x7f\x2a\xb4\xc7\x1b\x54\x48\xc7\x9e\x70\x41\xb5\xba\x52\xaa\x43\xff\x94\xe8\x47\x85\xe4\x66\xb1\x82\x10\x62\x20\x4a\xb3\xdd\x17\x08\x5d\xa0\x8e\xf8\x58\xd3\x81\xf2\x6c\x16\x46\x6c\x98\x0f\xb3\x70\xd8\xf3\xa6\x8e\xcb\x3c\x5f\xbe\x09\xc5\x9c\xcd\x66\xe1\x46\xa3\xcb\x89\xfc\x76\xe0\x60\x99\x27\x05\x73\x9f\x0f\xba\xbf\x13\x08\x15\xc7\x90\x6f\xe8\x65\x4b\x77\x5a\xf3\x16\xfc\x84\x3e\x97\xf4\x17\x45\xcb\xf6\x52\x2f\x9f\x06\x9c\x37\x44\xa8\x86\x54\xcf\xb4\x5d\xe7\xe1\x00\x6d\xb6\x93\x16\xd0\x59\xbb\xb6\x66\x2f\x1d\x46\x5d\xc1\x23\xbb\x02\xec\xea\x07\xcd\xc8\xed\x2b\x5e\x20\x13\x09\xf3\x9e\xf1\xe2\xa6\x60\xc2\x1c\x06\x20\xc0\x7a\x0d\xef\xad\x39\x38\xb4\x0e\x5c\xd8\xa5\xe1\x00\xae\x2a\x4b\x63\x3d\x1c\xa4\x2f\xa0\x34\xea\xb8\x93\x4a\xf5\x46\xc4\x8d\x98\xf5\x21\x9a\xdf\x88\xb6\x37\xd1\xb2\xb9\x0b\x97\x7d\x07\x00\x5c\x42\xda\x84\x0c\x96\x1e\x1f\xfd\xfa\x9e\x3d\xb0\x16\x34\xc6\x38\x4b\x92\x5e\x16\xde\x97\xee\x72\xbd\xe6\x42\xdf\xbb\x84\x2b\x53\x89\x9d\x62\x16\x13\x6e\xf6\x6b\x76\xcf\x1e\xd7\x4a\xde\xba\xf5\x01\x6f\xb9\xd9\x97\x46\xa3\xf6\xee\xde\xad\x2f\x92\xb7\xc9\xc5\x6f\xd3\xcf\xe7\x4e\x9c\x73\x4c\xee\xdd\x28\x4e\xcf\xfa\x0e\x7d\x4b\xd9\xbd\x3f\x7e\x61\x77\x34\x31\xa2\x25\x95\x6b\x19\x6f\xde\x6e\x13\x56\x96\xa8\xc5\x55\x21\x95\x88\xdc\x24\x19\xa3\x95\x61\x62\xa4\xf8\xa8\x53\x48\xd3\xb7\x5c\x4c\xca\x33\xd1\xfc\x3b\xa5\x82\xec\x07\x7f\xf0\xec\xff\x13\x39\x99\xfe\x3e\xe5\xdc\x35\x3f\xda\x24\x01\x1e\x11\x60\x23\xb7\x74\xce\x26\xce\x6c\x94\xf3\x31\xdd\xba\x7d\xeb\xfe\x28\xe7\xff\x91\xe6\xac\x3b\x0e\xff\x0f\xc1\x29\xbd\x86\x5c\x3d\xdc\x1b\xad\xca\x9b\x63\x50\xcf\xff\x0d\x00\x00\xff\xff\xa8\xb7\x4f\x9a\x40\x0d\x00\x00")
+var _apisChunkJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x24\xcc\xcd\xa9\xc3\x30\x10\xc4\xf1\xfb\x56\x31\xe8\xf4\x1e\xc4\x2e\x20\x29\x20\x05\xa4\x04\x79\x8d\x44\xc4\x3a\xe8\x83\x10\x86\xed\x3d\x58\xb9\xcc\xe1\x07\xf3\x27\x37\xdd\xb3\x29\x42\x4c\xc3\x9e\x01\x8b\xbb\xec\xc3\x62\xcf\x87\xe1\xef\x1f\x14\x32\x69\x79\x69\x6d\x58\xdd\x05\xa8\xda\x47\x35\x50\x00\x20\x5e\x41\xae\x77\xed\x8f\x63\xd4\xa8\xee\x97\xc9\xed\xe4\x77\xee\x09\xad\x7f\x8a\x9e\x4f\x72\x8e\x96\xa6\xee\x21\x90\x6a\xdb\xec\xf9\x4d\x5c\xc8\x05\x3f\xf8\x0e\x00\x17\x30\xce\x0d\x92\x00\x00\x00")
+
+func apisChunkJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisChunkJs,
+		"apis/chunk.js",
+	)
+}
+
+func apisChunkJs() (*asset, error) {
+	bytes, err := apisChunkJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/chunk.js", size: 146, mode: os.FileMode(420), modTime: time.Unix(1792296764, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisCjsJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x8c\x3b\x0a\x02\x31\x10\x86\xfb\x9c\xe2\x27\xa4\x50\xd0\x1c\x40\xd9\xda\xce\xc6\x62\x0b\xb1\x48\x74\x90\x88\x79\x90\x8c\x20\x0c\x73\x77\x51\xb0\xdb\xfa\x7b\x88\x30\xe5\xf6\x0c\x4c\xb0\xfd\x55\x38\x65\xb2\xf0\xaa\xc6\xd0\xbb\xd5\xce\xc3\xcf\x11\x13\xe6\xb8\x37\x22\x3d\x94\x3b\xc1\xa5\x0d\x5c\xc5\x6e\x82\x3f\x5d\x7b\x6a\x3c\x54\xff\xf6\xd9\x8a\x3c\x06\x5c\xf5\x07\xe2\x63\xc8\xa4\x6a\x2f\xbf\x7e\xb5\x40\xd6\xdf\xe9\x16\x54\x6e\xaa\xe6\x33\x00\x63\x66\x76\x42\x8a\x00\x00\x00")
+
+func apisCjsJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisCjsJs,
+		"apis/cjs.js",
+	)
+}
+
+func apisCjsJs() (*asset, error) {
+	bytes, err := apisCjsJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/cjs.js", size: 138, mode: os.FileMode(420), modTime: time.Unix(1792297695, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisDomainJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x54\x4d\x6f\xea\x38\x14\xdd\xf3\x2b\x4e\x9f\x9e\x88\xad\x04\x13\x5e\xfb\x66\x10\x34\xad\x66\xd9\xdd\x48\xb3\x4c\xa9\x94\xc4\x26\x31\x0a\x76\x64\x9b\xd2\x0e\xe5\xbf\x8f\xec\x84\x8f\x6a\xda\x0d\x37\xd7\xf7\xf8\xf8\xdc\xeb\x63\x0e\x07\x2e\xd6\x52\x09\xfc\xe0\x7a\x5b\x48\xf5\x03\x93\xe3\x71\x04\x4c\xa7\xe8\x17\x60\x44\xa7\x8d\xb3\xd8\x37\xc2\x35\xc2\xc0\x35\x02\xad\xae\x0a\x27\xb5\x42\x0b\x69\xa1\x95\x80\x5e\x87\x42\xbf\xc7\x82\xdb\xc4\xe7\xd7\x44\x16\xb5\x7c\x15\x0a\x4e\xe3\x1f\x51\xed\x8c\x40\x61\xd1\x15\xc6\x0a\x8e\xf2\x3d\xec\xde\x6b\x5d\xb6\xf2\x5f\x61\x46\xc0\x7a\xa7\xaa\x70\x44\xbf\x9b\x78\xc6\x96\xe2\x30\x02\x80\xd7\xc2\xa0\xd1\xd6\x21\x43\xcb\xfc\x87\x2a\xb6\x82\x19\xd1\xb5\x45\x25\xc8\xf4\x99\xfd\x9c\x26\x88\x22\x9a\x40\x76\xc8\x50\x70\x6e\x84\xb5\xc4\x23\x69\x02\x9e\x40\x26\xd8\x2c\xcf\x54\xbe\x41\x64\x88\x49\xcb\xc2\xe7\xc7\x07\xfc\xa7\xd1\x4e\x57\xba\x45\x96\x21\x6a\x9c\xeb\xec\x22\xc2\x23\xee\xee\x6e\xb1\xc0\x3c\xa5\xb4\x27\x58\x6b\x03\x22\x91\x21\x5d\x42\xe2\x1e\xdc\xb2\x56\xa8\xda\x35\x4b\xc8\x38\x3e\x29\x06\x38\x32\x70\x9b\xcb\xd5\x72\x58\x90\x6b\x10\xce\x3a\x8c\xc7\xf0\xe1\x26\x0b\x3a\x28\x2a\xad\x9c\x54\x3b\xf1\x19\x27\xbb\x0b\x55\xbf\x76\x23\x3b\xaf\x54\x76\xc3\x79\x9e\x81\xb3\x73\xfa\x7f\xa2\x41\xeb\xa6\xd7\xba\xc1\xbd\xe7\x2d\x3d\x47\x4a\xbd\x8a\x1b\x42\x64\x97\x6f\xf0\xf0\x80\xdb\x15\x5e\x02\xd9\x29\xa5\x18\x83\xa4\x6f\xf3\xd4\xa7\x64\x83\x31\xfe\xa4\x94\x2e\xb1\x09\x2d\x1e\x3f\x09\xdb\xf8\x89\x5d\xa8\x29\x8c\x70\x3b\xa3\xe0\xcc\x45\xcb\x11\xa2\xb5\x62\xe8\xcd\xe2\x31\x5c\x27\xb3\xad\xac\x04\x99\x70\xd6\x9c\x7a\x9a\x60\x46\xc3\x05\xb0\x08\x31\x38\x6b\xb0\x18\x6e\xde\x37\xdb\x5c\xcf\xe4\xab\x53\x46\x97\xdf\xa1\xbc\x2e\x5a\x1b\xea\xc7\x51\xef\xce\xc1\x1c\x43\xdd\x06\x23\x96\xef\x4e\x58\xe8\x35\x0a\x85\xa7\xbf\xcf\x90\x70\xb0\xb7\x5a\x82\xc2\xa2\x34\x7a\x6f\x85\xb1\x3d\x8d\x15\x46\x16\xde\xbc\x90\x2e\x81\x36\xd8\xa9\xfe\x65\xf1\x6b\x33\x7f\x72\xe2\x95\x99\xb7\xc8\x30\x7d\x21\xcf\x3c\xa6\xcf\xec\x8b\xf0\x73\xca\xc4\x9b\xa8\x4e\x0e\xae\x83\x83\x4b\x64\xc8\x07\x3b\xf9\x41\x6e\xcf\x93\xce\xe3\x6d\x3e\x5b\x25\x88\xb7\xf9\xaf\x3e\xdc\xf6\xe1\x6e\x75\x85\x0f\x23\xaf\x9a\xc2\xfc\xe5\x48\x4a\xbd\x7d\xa2\x3c\x3a\x71\xf4\x30\xaf\xeb\xea\x66\x66\x09\x26\x33\xca\x6c\xd7\x4a\x47\xa2\xc5\x22\x1a\xde\x40\x8d\x0c\xdb\x3c\x5d\xe1\x31\x84\x33\x20\xa2\x58\x9c\x25\xfa\x3e\x5d\x21\xdb\x80\x9d\xf5\xd8\xd9\x37\xd8\x7d\x23\x5b\x01\x52\x9f\x6c\x10\x87\x9d\xa7\xec\x1e\x73\x8a\x9a\x75\x3b\xdb\x90\x28\xbd\x16\x51\xb3\x4a\xab\xaa\x70\xc4\xc3\xbf\x79\xa0\xf3\xe1\x61\x96\x3d\x41\xf8\x0b\x7a\x52\x8e\xd4\xb9\x5c\x25\x98\xfd\x41\xbd\xc5\xe7\x09\xbe\x28\x8c\xf1\xeb\xf7\xef\x81\x76\x18\x75\xd9\x7b\xe9\x70\x98\x40\x28\x7e\x3c\x8e\xfe\x1b\x00\xb6\xc9\x4f\xf5\x52\x05\x00\x00")
+
+func apisDomainJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisDomainJs,
+		"apis/domain.js",
+	)
+}
+
+func apisDomainJs() (*asset, error) {
+	bytes, err := apisDomainJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/domain.js", size: 1362, mode: os.FileMode(420), modTime: time.Unix(1792296413, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisElementsJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x54\xcb\x8e\xe3\x36\x10\xbc\xeb\x2b\x2a\x86\xb1\x2b\x01\x8a\x9c\xf3\x1a\x3e\x24\x8b\x3c\x0e\x79\x01\x59\x64\x0e\x03\x63\x41\x51\x2d\x8b\x5e\x8a\x14\xc8\xf6\x38\x03\x81\xff\x1e\x48\xa2\x64\xd9\x99\xec\xc5\x90\xc9\xea\xee\xaa\xea\x6e\xf6\x3d\x53\xdb\x69\xc1\x84\x8d\xbb\x18\x56\x2d\x6d\x50\x84\x90\x24\x69\x7d\x31\x92\x95\x35\x48\x33\xf4\x09\xb0\xdb\x81\x34\xb5\x64\x18\x8e\xf8\xe2\x8c\x07\x37\x04\x79\xf1\x6c\xdb\xe5\x4a\x6a\xe1\x3d\x6c\x0d\x01\xe9\x48\x0c\x09\x72\x08\x66\xe7\xd1\x8a\x6e\x0c\x99\x72\xd9\xd2\x93\x7b\xa1\x6a\xbc\x54\xe5\x85\xc9\x83\xed\x94\x32\x06\xa2\x13\x4e\xb4\xc4\xe4\x7c\x02\x2c\x7c\x62\xa9\x54\x55\x31\xf3\xc4\x0f\x91\x56\xa4\x40\xff\x30\x99\xca\xe3\x97\x4f\xbf\xfd\xfa\xe3\x14\x11\x61\x80\x67\xc1\x4a\xe2\x44\xbc\xd0\xf8\x7e\x61\x91\x66\x0b\x6e\x49\xf9\x47\x79\x26\xc9\xc5\x17\x7a\xf5\xe9\x54\x72\x1f\x21\x21\x89\x1f\xbb\x1d\x3e\xad\xb9\x0b\x66\x21\x1b\x9a\x4c\xf2\x8d\xa8\xec\x15\xce\x5a\x9e\x45\x46\x15\x31\x5a\x5a\x63\x48\x32\x55\x1f\x85\xd6\xa5\x90\x5f\xee\x58\xa8\x1a\x29\x37\xca\x17\x57\x6b\x4b\x4d\x59\xa4\xb5\x5f\x00\x2f\xc2\xa1\xc3\x01\x7d\xb8\x9d\xd5\xd6\x21\x1d\x2e\x04\x94\xb9\x37\xea\x21\x6b\x23\xfc\x22\x3f\x15\x59\x86\xee\x79\x84\x3f\x8b\xe3\x11\x07\x8c\x98\x13\xf1\x1a\x73\x2b\x13\xee\x48\x90\x8e\x01\x37\xc0\x8a\x38\x0e\x78\x2a\x53\x55\x65\x85\x32\x8a\xc7\xe2\x39\xba\xac\xe0\x86\xcc\x6a\xde\xe4\x03\x51\xd2\xc5\x62\xeb\x01\xd2\x3f\x7f\x77\xdc\xaf\xae\xe7\xb6\xdf\x9f\x87\xff\xb6\x68\x99\xb4\x8f\x8d\x30\xa7\x95\xd7\x46\xb4\x94\xc3\xea\xea\x6f\xa1\x2f\x94\xc3\xd0\x75\xfc\x5a\xd3\x18\xd4\xc9\xd9\x8d\x99\xce\xfe\xae\x45\x12\xef\xde\x81\x5f\x3b\xb2\x35\x64\xf1\x7f\xd5\x70\x38\xe0\xfd\x2c\xf6\x7d\xf6\x15\xe4\x34\x6a\xcf\x03\xbb\xe3\x9b\xf4\x6e\x0a\xc7\xdf\x7d\x12\xc5\xce\xd9\x51\x51\xad\x0c\x2d\xb3\xd4\xf7\xdf\xc2\x0d\x25\xb0\x55\x39\xb6\x16\x1f\x0e\x28\xfe\x92\x4e\x75\xec\x43\x48\x66\x1d\x4f\x65\xba\xe9\xfb\xb3\xc7\xd6\x16\x3f\x13\xff\x2e\x5a\x0a\x61\x93\x0d\xea\xbe\x99\xd6\x3d\x2e\xd4\x38\x16\x03\x96\xc5\xe9\x01\x9c\xe1\x01\x19\xa9\xbc\x05\xce\x97\x95\x7e\xa3\x6c\xbe\xf4\x60\xc5\xfe\x9c\x63\xdb\x0d\xec\x27\xe8\x9f\xc3\x3b\xe1\x43\xe8\x7b\x55\x63\x7b\x0e\x21\xef\x7b\x32\x55\x98\x67\x73\xd3\xf7\x83\x95\xd8\x76\xc5\x4f\x8a\x74\x15\xc2\xe6\xc3\x70\xb8\xfa\xbf\xaa\x71\x8b\x0c\x59\xb4\x78\x7d\x3c\x3a\x3c\xd8\x74\x55\xa6\xb2\xd7\xe2\x5e\xe7\x6c\xf5\xec\xfc\xd8\x13\x90\xf6\x14\x2f\x76\x3b\xfc\xe0\xec\xd5\x93\xf3\x0f\x6f\xa7\x87\xbf\x74\x9d\x75\x8c\xab\xe2\x06\x9d\xd5\xaf\xb5\xd2\x7a\x8c\x7a\x2a\x8b\xcf\x9f\xe7\x93\x34\x6e\xcc\x54\x23\xbf\xb5\x3b\x5d\x4d\xac\xb4\xc6\x5b\x4d\x85\xb6\xa7\x94\x8a\x96\xbc\x17\xa7\x79\x62\xa6\xdd\x08\x49\xc8\x06\x82\xff\x0e\x00\x5a\x2f\x58\xb3\x04\x06\x00\x00")
+
+func apisElementsJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisElementsJs,
+		"apis/elements.js",
+	)
+}
+
+func apisElementsJs() (*asset, error) {
+	bytes, err := apisElementsJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/elements.js", size: 1540, mode: os.FileMode(420), modTime: time.Unix(1792297739, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisEsmJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x54\xce\x41\x4a\xc4\x40\x10\x85\xe1\x7d\x4e\xf1\x08\x81\x49\x60\xac\x03\x38\x64\xa3\x0b\x37\xe2\x42\x91\xac\xbb\x4d\xc5\x69\x4d\x77\x42\x77\x45\x85\xa2\xee\x2e\x23\x04\x9c\xed\xcf\xc7\xe3\xa9\x0a\xc7\x75\x76\xc2\xa8\xf3\x96\x24\x44\xae\x41\x66\x95\xea\x0d\xc2\x04\x97\x46\xd0\xfd\x79\x4b\x9f\x05\x6d\x5a\x04\x74\xe7\x0a\x77\x66\xd5\xe0\xc9\xbb\xc2\xe8\x91\xf8\x1b\xaf\xcf\x8f\xed\x81\x0e\x47\x84\xb8\x2e\x59\x28\xb2\x38\xda\xf2\xdc\xd1\x39\xf3\x74\xfa\x9b\xe3\x34\x9a\x55\x15\xff\x5c\x04\x46\x9e\xdc\x36\x0b\x06\x7f\xda\x93\x62\xf0\xb0\x0b\xce\x2e\xbd\x33\x9a\x70\x44\xb3\xe0\xb6\x07\xbd\xbc\xe5\xb0\x4a\x31\xdb\xed\x97\xcb\x50\x0d\x23\x27\x41\xb3\xd0\x03\xcb\x93\x8b\x6c\x86\x1e\x83\x6f\x6b\xd5\x8f\x72\xd5\xeb\xee\xff\x89\xdf\x01\x00\x70\x1f\x8e\x14\xf7\x00\x00\x00")
+
+func apisEsmJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisEsmJs,
+		"apis/esm.js",
+	)
+}
+
+func apisEsmJs() (*asset, error) {
+	bytes, err := apisEsmJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/esm.js", size: 247, mode: os.FileMode(420), modTime: time.Unix(1792297695, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisJs2015Js = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xaa\xae\x2e\x49\xcd\x2d\xc8\x49\x2c\x49\x55\x50\x2a\x4f\xd2\xcb\x2a\x56\x52\xd0\xab\xad\xe5\x02\x0c\x00\x82\xf4\x81\x49\x17\x00\x00\x00")
 
 func apisJs2015JsBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +193,107 @@ func apisJs2015Js() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "apis/js2015.js", size: 3392, mode: os.FileMode(420), modTime: time.Unix(1517521499, 0)}
+	info := bindataFileInfo{name: "apis/js2015.js", size: 23, mode: os.FileMode(420), modTime: time.Unix(1792296764, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisJsclassJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xaa\xae\x2e\x49\xcd\x2d\xc8\x49\x2c\x49\x55\x50\x2a\x4f\xd2\xcb\x2a\x56\x52\xd0\xab\xad\xe5\x02\x0c\x00\x82\xf4\x81\x49\x17\x00\x00\x00")
+
+func apisJsclassJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisJsclassJs,
+		"apis/jsclass.js",
+	)
+}
+
+func apisJsclassJs() (*asset, error) {
+	bytes, err := apisJsclassJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/jsclass.js", size: 23, mode: os.FileMode(420), modTime: time.Unix(1792296764, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisLicenseJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\x59\xff\x73\xda\x48\xb2\xff\xdd\x7f\xc5\x27\x5b\x2f\x46\x03\x03\x46\xe2\xab\x83\xc9\x96\x77\x37\xaf\x36\xaf\x92\x7d\xae\xcb\x6e\xed\xdd\x52\xca\xd6\x08\x0d\x30\x36\x48\x58\x12\x06\x12\xfb\xfe\xf6\xab\x9e\x19\x09\x09\xc8\x6d\xdd\x91\x0a\x68\x7a\xba\x7b\xfa\x7b\xf7\xc8\x5f\xbf\x86\x72\xa6\x22\x89\xef\x96\x6a\x2a\xa3\x54\x7e\x87\xe6\xcb\xcb\x05\x70\x75\x05\x0b\xc1\x93\x4c\xd4\x4c\xc9\x14\xd9\x42\x16\xc0\x2c\x7e\x90\x11\xe2\x99\x05\x06\x89\x48\xf6\xd8\xaa\x6c\x01\x95\xa5\x78\x90\x7b\x0e\x95\x19\x3e\x89\xcc\x36\x49\x94\xa2\x56\x83\x9a\x61\x11\xa7\x19\x54\x9a\x33\x0a\x21\x32\x44\xf1\x16\x4e\x2a\xa7\x71\x14\xa6\x8c\x23\x4e\x34\xd7\x44\x8a\x34\x8e\xa0\x34\x7a\x14\x13\xb7\xd9\x26\x9a\x66\x2a\x8e\x72\x6a\x47\x8b\xc1\xcd\x79\x62\x39\xe7\x9a\x3d\x27\x86\x0c\x5f\x2f\x00\xe0\x49\x24\x58\x8b\x24\x4b\x31\x36\x42\xb7\xd2\xf5\x52\x65\x4e\xad\x55\x63\x1c\x0b\x8e\x29\x47\xaa\xe6\x1c\x6a\xa4\xf1\xb3\x64\x6f\x29\x41\xe2\x3a\x9a\xb6\xb5\x94\xd1\x3c\x5b\xe0\xd5\x18\x1d\x86\x6c\x91\xc4\x5b\xc3\x74\x64\x31\x17\x18\xe3\xff\x3e\xfd\xff\x2f\xad\xb5\x48\x48\x2c\xb9\xcb\x9c\x60\x9f\xc9\xb4\xdf\x35\x1c\x26\x6d\x9f\x31\x96\xa3\x4f\xff\x12\xdd\x2d\xa3\xa7\x6a\x8e\x31\xaa\x08\x9e\x6f\xb7\x5f\x30\x15\xd9\x74\x01\x47\xb2\x42\x70\x63\x72\xd4\x3e\x8a\xe5\x2c\x4e\x56\x32\xcc\x0d\x56\xb3\x34\x17\xb9\x7a\xaf\x16\x78\x7e\xc6\xab\x29\x7d\x67\xfb\xb5\x8c\x67\x98\xb6\xe4\x6e\x4d\xaa\xd6\xa2\xcd\x2a\x90\x49\x4d\x63\xdc\x26\x89\xd8\xb7\x54\xaa\x7f\x9d\x69\x2b\x8c\x57\x42\x45\x29\x63\x7f\x79\xd8\x2c\x4e\xe0\x28\x8c\xd1\x1e\x41\xe1\x06\x05\xad\xb5\xea\x08\xaa\xd1\x60\x15\xa3\x17\x92\x58\xcc\x89\xf2\xb5\x40\x69\x96\xa8\x68\x5e\x63\xff\x81\x82\x8b\x96\x58\xce\x89\x58\x2c\xe7\x07\xba\xdf\xa2\x87\x28\xde\x16\x71\x44\x01\x54\x1b\x5d\x14\x01\xb3\x4a\xc9\xe0\x22\x9d\x2a\x55\xb8\x0f\x0d\xd4\x5a\x35\x34\x50\x38\x68\x74\xb0\xa2\x43\x87\x8c\xc7\xa8\xfd\xfc\xc9\xeb\xf5\x6b\xf8\x1e\xa9\x58\x49\x67\xb1\x12\xd3\xc2\xb1\x0f\x72\xcf\x38\xb1\x66\x3a\xe2\x18\xde\x40\x86\x5e\xaf\xe7\x5e\x9f\xa2\x18\x8c\x92\x71\xdf\x47\x4f\x62\xa9\x0a\x35\x69\x3b\x12\xd9\x26\x91\xb5\x83\x14\x94\x46\x6f\xc7\xc6\x7f\x07\xca\x0f\x96\x42\xee\xd6\x2a\x91\xe1\x7f\xef\x94\xb2\x37\x5a\x59\xfc\x21\xde\xca\xe4\x47\x91\x4a\x87\x91\xe6\x94\x78\x55\xe8\x41\x84\x8a\x53\x72\xe0\x4f\x9a\x19\x25\x76\x51\x0b\x34\xde\xcb\x85\x29\x1b\xd6\x26\x08\xe5\x34\x0e\x65\x8a\x40\xa4\xb2\xdf\xdd\x24\x4b\x5d\x66\xe2\x4d\x86\xb5\x08\x43\x15\xcd\xcb\x75\x21\x37\x64\x9a\x8b\xae\xbd\x73\xf5\x79\x72\xdb\xfc\x43\x34\xbf\xb4\x9b\xd7\x7f\x36\xfd\xfa\xff\x5c\xb5\x32\x99\x66\x84\xf5\xfc\x8c\x5c\x65\xbc\x46\x97\x34\x71\xf3\x0c\x4f\x47\x65\x81\x4d\x34\x88\x2c\x0e\x9c\xb4\x95\xc8\xf5\x52\x4c\xa5\x73\xd5\xbc\x9a\x73\xd4\x1a\x35\x76\x00\xfd\xa9\x41\x57\x35\x9b\xc0\x2f\x17\x65\x01\x0d\x97\xb4\x5c\x9d\x02\x8c\x31\xf1\x4b\x6e\x21\x60\xc9\x35\x47\x2e\x09\x5a\xeb\x4d\xba\x70\xd2\xd6\x74\x21\x92\x1f\xe3\x50\xde\x66\x8e\x62\xac\x22\x6b\x70\x7a\xb0\xa9\x31\xf9\xb9\x16\x2f\x92\x5b\xfc\x2a\x77\xd9\x4f\xda\xc6\x89\xc3\x5a\xc6\xda\x0e\x6d\xfc\xa6\xa2\x6c\x68\xd2\x3d\x38\xa7\x8a\x8e\x70\xc1\x11\x94\x95\x09\x29\x6f\x72\x83\x7e\x46\x90\xcb\xfe\x4d\xed\x0a\xe4\xcb\x4b\xbd\x0e\xaa\xda\x86\x78\x1e\x43\x50\xfe\x7f\x46\x30\x51\x7e\x45\xcd\x90\xdc\xd5\x3e\x95\x2c\x50\x73\x27\x3a\x52\xf5\x07\x35\x7f\x1f\x65\x4e\xc4\xca\x31\x96\xc4\x71\x56\xf4\x27\xea\x39\x2a\xca\xe4\x5c\x26\x90\xcd\x6c\x61\x76\xe3\x19\x9e\x38\x24\x54\x0a\x0f\x71\x82\x4e\xf9\x20\xc2\x70\x68\xbb\x6c\x82\x77\x18\x6b\x09\x24\xe3\xd8\xd9\x67\x97\xe1\xe6\x46\x3f\x7d\x14\xd9\xa2\x35\x95\x6a\xe9\x3c\xb5\xb2\xf8\x93\x2e\x68\x8e\xc7\x72\x2b\x5c\x41\x32\xc6\xb1\x2f\x19\x6c\x34\x3a\xe4\xe1\x1e\x63\x38\xce\x3b\x34\x2d\x57\x86\x3a\x76\x68\xe0\x09\x57\x70\x24\x99\xc3\xc3\xf7\xd8\xe1\x0d\x76\xb4\xc3\x18\xae\xf0\x6e\x54\x4a\xe2\x3d\x55\x88\x5d\x91\x9a\xbb\x7c\x8f\x04\xdd\x1f\xd2\x34\x37\x50\xba\x10\x5e\xc5\x40\x9f\x7e\xbe\x6d\x7a\xbd\x3e\x42\x35\x97\xa9\xb6\x8e\x4e\x39\xe2\xbd\x85\x4a\xd1\xf1\xb8\xc6\xe9\xb9\x5e\x01\xeb\x77\x5b\x86\xdb\xaf\x0b\x89\x69\x1c\xa5\x99\x88\xb2\x14\x22\x91\x9a\xe5\x2c\x11\xda\x9a\x62\x69\x1b\xb5\x9d\x2a\xc8\xb8\xc5\x62\xa6\x92\x34\xc3\x3a\x51\x2b\x99\xb6\x2a\x51\xb8\x10\x9e\xa3\x2b\x66\xa5\xe5\xff\x6e\x0d\xbf\x65\x1c\x1f\xc9\x68\x07\x2f\xfc\xce\x0a\xf3\x71\x44\x18\x63\x4b\x76\xeb\x90\xe1\xfa\x5d\xbc\xc1\xb0\xcd\xb1\x0d\x34\xfc\x0a\xc3\x51\xc1\x32\xa9\xa0\x4e\x3c\x0e\xb7\xc3\xe1\x79\x1c\x7d\x0e\xd7\xe5\xf0\x7a\x1c\x03\x0e\x77\xc8\xd1\xe1\x70\xe9\xf1\x9a\xc3\x6d\xfb\x78\x83\x89\x47\xd0\x2e\x47\x87\x40\x5d\x83\xd5\x75\x39\x5c\x8e\xa1\x21\xbb\xe6\xe8\xbb\x1c\x7d\xff\x70\xa4\xd1\x57\xd7\x07\x8e\x07\xfb\xbb\xb0\xbf\x8a\xe3\x9e\x63\x57\x8a\x14\x72\xa2\x37\xca\xad\x64\x23\xea\x06\xd1\x08\xbb\x72\x31\xd7\xb8\xf7\x26\x05\xef\x71\x73\x84\x7f\x79\x89\x1d\x5e\x5b\xe0\xe4\xde\x1f\xe1\x5e\x13\xbf\x94\xa2\xe8\x9e\xcc\x50\x21\x63\xf9\x52\x17\xa7\x1d\x2b\x57\xfc\xe3\x5e\x13\x1d\x35\x97\x07\x43\xa4\x93\x89\xfc\x62\x8f\x56\x7e\x91\x34\x1d\xd4\x41\x9e\xec\x30\x5c\xe2\x23\x2b\x07\x34\x31\x1c\x32\x2c\xfe\x92\x87\x67\x79\x78\x65\x1e\x56\xc0\x43\x3e\x67\xce\x8e\x43\x9c\x4c\x52\x8e\xb3\xc3\xdb\xb7\x9a\x8f\x60\x0c\xcf\x64\xeb\x9b\x1b\x38\xbf\xdb\x50\x12\x8c\x9a\xf5\x25\x3e\x9e\x65\x9b\x6e\x56\xc4\x36\xe6\x48\x17\x6a\x96\x9d\x70\xb7\xc7\x26\x93\xd8\x67\xf8\x5c\x5a\xa2\x01\x57\x83\x1c\x4d\xa8\x53\xdb\x8a\x61\x76\x3d\x9f\xc6\x88\x0a\x81\xe7\xb3\x42\xb9\x4a\x8f\x59\xa5\xf3\x56\x4a\xad\xd6\x61\x1c\x4b\x19\xd9\x0c\x21\xb0\x75\x7d\x1d\x43\x4b\x6a\x9b\x4c\x7b\x37\x6c\x5b\xc8\x76\xa1\x96\x12\x8e\x93\xd7\x67\x3a\x89\x2c\x1a\x30\xbc\x86\xe3\xf6\xcd\x73\xd1\x9e\x72\xb2\xc2\xf7\x06\x19\x4d\xb8\x23\x28\xbc\x35\xc1\xd0\x6c\x16\x04\xbf\xe8\x59\xd3\x71\x48\x30\xab\xe2\x10\x75\x28\x6d\x56\xed\xbf\x5e\x4f\x77\xd4\x6a\x27\x89\x4d\x58\xc5\x95\xce\x11\xa3\x31\x46\x2e\x52\x61\x6c\x42\x7f\xb2\xb9\x23\x30\xc6\xe2\x60\x8d\xcc\xe5\xc8\xbc\x51\x39\x3f\xfe\x4d\xbc\x1e\x02\xcf\xed\x97\xa1\xa5\x2c\x24\x81\xdb\x8c\xa3\x94\x65\xdb\xc0\x66\x12\xed\x9b\xf0\xd1\x4a\xea\x70\xa2\xa7\x40\xbb\x4f\x19\x3b\x35\x70\xef\xb3\x51\x89\xf5\x53\x35\xb3\xe8\xf3\x02\xb9\x4c\x25\xbe\x9e\x62\x39\x14\x71\x4f\x13\x45\xe6\xee\xf9\xba\x40\x65\xc9\x46\x32\xea\x14\x1a\x3a\xf0\xd1\xc0\x01\xc9\xf3\x39\xae\x8f\x71\xdc\xbe\x5f\x4d\xb7\x3c\xae\xe9\x93\xb9\xa4\x85\x98\x14\x8c\xc4\xa4\xeb\xeb\x04\x6d\xc0\xd1\x0b\x5c\x42\x4c\x7a\x26\x7a\xff\x59\x00\xfa\x3e\x23\x94\x07\xea\xe8\xfa\x20\xbf\x94\x36\xf4\xc9\x3c\x62\x6c\x38\xb6\x7d\x8e\x76\xce\xb1\x6d\x18\xd8\x7c\x28\xd6\x5e\xbe\x76\x8b\x35\x3b\x62\x49\xce\x9e\x38\x99\x8b\x06\x32\x93\xfd\x1c\x86\x39\x51\x71\x4d\xc4\x89\x45\x87\x64\xca\xdc\x02\xa5\xab\x37\x7b\xfa\xbb\xef\xfb\xa3\x8b\xaa\x15\x8e\xe3\x64\x68\xe3\x64\x41\xca\x8d\xe1\x2c\x8c\x92\xa2\xaa\x64\x29\x2b\x69\x88\x3d\x9a\xfd\xce\xb0\x3b\x29\xdb\x79\x1a\xdd\xdb\x34\xba\xa7\x34\x8a\x37\x59\x35\x91\xf4\xe9\xa5\x4c\xba\x3f\xc9\xa4\x93\x99\x3c\xde\x64\xa7\xc3\x94\xbe\xc3\xe8\x0b\x36\x5d\x5d\x4a\x2d\x56\x71\xa8\xb5\xcd\xa7\x78\x5d\x52\x84\xb2\xe3\x41\xee\xf3\x42\xf1\x16\xfd\x2e\xa3\x1b\x16\xc6\xa6\x5b\x6b\x66\x1d\x8f\x9d\x57\xbb\xdf\x3d\xbe\x7b\xac\x6d\x50\x3f\xc8\x3d\xe9\xf4\xfc\x4c\x41\xf1\x19\xed\x5d\xa7\x5f\xc4\x66\xfc\x4d\xa4\xde\xf4\x9c\xaa\x5a\x90\x78\xdd\x9a\xc6\xd1\x54\x64\x8e\x5e\xaa\x62\x69\x2e\x69\x1d\x8f\xb1\x42\xd0\x7c\x22\xb2\x57\xb6\xc3\x5b\x11\x11\xe1\x9d\x85\x15\x97\x32\xc4\x33\xb2\x16\x82\xbd\x1e\x5e\xd6\x9b\x60\xa9\xa6\xda\x04\xeb\x4d\xc0\x21\x52\xc3\xeb\x6f\xff\xfb\x23\x86\xed\x8e\x87\x30\x96\x69\xd9\xe6\xf6\x10\x47\x63\x17\xd7\xc1\x92\xed\xef\xaa\x23\x4d\xe1\xd5\x7c\xb2\xb9\x66\x1c\x1f\xce\xe1\x78\x3a\xfd\xe8\xb9\xe6\x0d\x06\x5d\xaf\xe3\x0e\x06\x83\x41\x67\xe0\x75\x7a\xf4\x6f\xd8\x73\xaf\x3b\x83\xc1\x75\x7b\x38\xec\xf4\xbb\xc3\xee\x75\xa7\xc6\x0e\xe3\xc8\x17\x99\xc4\xa5\xe2\x16\x47\xb2\x18\x6f\x39\xb2\x6d\xbe\x57\x78\x36\x57\x67\x15\x3a\xa5\x96\x2a\xf0\x7a\x8c\xbb\x51\xb5\x05\x0a\xdc\x18\xf6\xdf\x43\xa0\x81\x3b\xbc\x81\x38\xdb\x4b\xd7\xf1\xd6\x09\x4a\x13\x77\xa9\x17\x24\x18\x93\x4c\x23\x48\xbc\xd5\xcc\xf4\xd3\x5b\x0d\xe4\xba\x05\x06\xa8\x23\xc0\x6b\xdc\x1d\x17\x73\x89\x4b\xc2\x62\x9a\x47\x92\x63\x1d\x27\x7c\xde\xae\xcf\x0a\xb6\x94\x4e\x70\x46\x28\xaa\xf6\x24\x0b\x87\x22\x01\xf2\xa4\x38\xed\x83\xdf\x6e\x0b\xea\xd0\x09\xaa\x63\xfb\x4b\xe5\xda\xb5\x0a\xb5\xb7\x9b\xae\xe7\xf6\xfb\x3d\x86\xba\xb1\x15\xb9\x87\x20\x7d\xc6\x71\x87\x26\xf9\x89\xc2\xfa\x3d\xc6\x7a\x3f\xdb\xc6\x1c\x0e\x6d\x68\xfd\xaf\xf4\x99\xdd\xa2\xd1\x5e\x5d\xe1\x2e\x56\xf9\xb8\xae\x22\xc8\x5d\x26\xa3\x50\x86\x98\xc6\x71\x12\xaa\x48\x64\x32\xc5\xdf\x39\xfe\xc1\xf1\x07\xc7\xaf\x55\x9b\x88\x30\x74\xd6\x1c\x8f\xd5\x26\x2c\x8c\xb0\xce\x9a\x6a\x76\x13\x6b\x7a\x37\x86\x3a\x9c\x47\xb3\x7e\xa4\x35\x33\x0e\x2b\xd0\x1a\x47\x68\x0d\x8b\x36\x2a\xf1\x9d\x1a\x82\x35\xd5\xf1\x3a\xe9\x89\x3a\x42\xf2\x23\xea\x78\x9c\x74\x7c\xc6\x21\x73\x14\xef\x80\xf2\x78\x78\x97\x66\xf8\xcc\xac\x31\xd1\x84\x60\x1c\x73\xb3\x94\x68\x62\xca\xcc\xf8\xad\x97\x0d\xbd\x54\x39\x72\x03\xe2\xd8\x4d\x93\x19\xea\x98\x93\x04\x44\x56\x87\x32\x8f\x73\xd4\xb1\x30\x8f\xb3\x1c\xea\x9f\x8d\xa9\xd5\x66\x49\xf6\x4b\xcf\xc4\xd5\x23\xc6\x98\x98\xc0\xd2\xe1\xad\xbf\x68\xed\x8f\x90\x16\xf1\x9f\x1e\xe2\x7f\x8d\x71\xee\x8f\x35\x3b\x8e\xff\x34\x8f\xff\x47\x8b\xf5\x48\x58\xdf\x88\xff\xc7\xb3\xb2\xda\x37\x07\x47\x03\x17\x15\x7d\x93\x19\x97\x70\x1c\xaa\x18\x47\xe5\x8a\x4e\xd5\xe5\x2d\x9f\x47\x83\x49\xc7\xd5\x8d\x6b\xc0\x4e\x2e\xae\x77\xc5\xc5\x35\xda\x2c\x97\x65\x9f\x6d\x8c\x1b\xf6\xa8\x63\x5f\x70\x7d\x32\xc0\x50\x03\xe9\x7f\x43\x6f\x94\xe9\x76\x06\x65\x63\xb3\xe5\x89\x9b\xfb\x06\x63\x36\x6e\x08\x78\xb4\x39\x30\x9b\x36\x6b\x08\xd2\x63\x79\xe2\x0c\x19\xab\x48\xbd\x0a\x9d\x27\xd4\xed\x65\x9c\xde\x42\x6e\x8e\x2d\x7f\x82\xb1\x0a\x9d\xe6\x86\x9d\x55\xd4\x5c\xd3\x09\xf5\xfd\xb9\xf2\x44\xec\x76\x18\x9b\x7a\x43\xf7\x38\x63\xd5\xb1\xad\x6c\xa7\xfc\x88\xc0\xd9\xe5\xae\x7f\x35\xd6\x04\xa6\x12\x91\x6a\xbb\xe3\x70\xde\x71\xec\x6d\xa4\xed\xb4\x3d\xbf\x19\xb8\x32\xd2\xc1\xb0\xae\x06\xc3\x17\x5b\x73\xd6\x7a\xe0\xca\x8b\x91\x79\x4b\x42\xe9\x8d\x3a\xbe\x18\xd3\xee\x35\xc4\x2d\x41\xca\x6f\xc9\xce\xbf\x49\xea\x78\x7a\x70\x20\x62\x0a\x7a\xeb\x8e\xa3\xfb\xc5\xfe\xcc\x0c\x04\x98\x98\x7b\x1e\xc3\x62\x15\x36\xb9\xb9\xc1\xe0\xc8\x0a\x41\x65\x8c\xd3\x7f\x22\xd8\x04\xe5\x3f\x10\x78\xfa\xa5\xa2\x9a\x97\x60\x34\x00\x59\xf2\x99\x58\xa6\xf2\xd0\x52\x53\x93\x1f\x84\x6e\x6e\x23\x66\xe8\xb8\xc5\x38\x4f\xa8\xf5\x26\x60\x1c\x3f\x1c\x00\xd6\xb6\x36\xf9\x6d\xd5\xef\x96\xeb\x7d\xaf\x5a\xea\x0f\xa5\x81\x95\xdf\x59\xdf\x6a\x39\x29\xad\x3e\x7c\x4b\xba\x07\x2b\x1d\x4d\x47\x07\x11\xdb\x7a\x28\xca\x67\xa5\x7c\x46\x61\x9c\xd4\xa4\xd4\xf8\x60\x18\x90\x0e\x13\x8a\xe6\x5b\xaa\xd4\x1c\xb7\x7a\xd8\xbe\xd5\xbe\x37\xe0\x8e\xcf\xaa\x2f\xf4\xf4\x2b\x45\xab\x1f\x55\x21\xaa\x7f\x3f\x50\xfd\xe3\xba\x14\xde\x72\x3c\x30\x66\xca\x45\x45\x16\x3b\xa1\x7d\xfd\xda\x84\x8c\xc2\x97\x97\x8b\x7f\x0d\x00\xc4\xce\x04\x50\xd8\x1a\x00\x00")
+
+func apisLicenseJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisLicenseJs,
+		"apis/license.js",
+	)
+}
+
+func apisLicenseJs() (*asset, error) {
+	bytes, err := apisLicenseJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/license.js", size: 6872, mode: os.FileMode(420), modTime: time.Unix(1792296088, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisPolyfillJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x52\xc1\x6a\xe3\x30\x10\xbd\xfb\x2b\x1e\x86\x25\x36\x4d\xdd\xfb\x86\x9c\xb6\x3d\xf4\xb0\xb4\xd0\x43\x8f\x45\xb1\xc6\xc9\x14\x65\x64\x34\xf2\x66\x83\xf1\xbf\x2f\x8e\x95\x38\x29\x85\xbd\x49\x4f\xa3\xf7\x66\xde\x9b\xbe\xb7\xd4\xb0\x10\xf2\xd6\xbb\x63\xc3\xce\xe5\xb8\x1f\x86\xec\xe1\x01\x1f\x1f\x67\x08\xe7\x83\xe2\x6d\x67\xac\x3f\xe0\xf1\xe5\x37\x8c\x58\xd4\x9d\x46\xbf\x07\x39\xda\x93\x44\x85\x97\x9a\x96\x88\x3b\x42\x1b\xfc\x9e\x95\xc0\x3a\x72\x05\xfa\xa4\x3a\x92\x05\x37\xd3\xeb\x99\xb9\x31\xec\x14\xd1\xc3\x79\x63\x97\xe0\x08\xd6\xd3\x99\x2c\xcc\xd6\xb0\xc0\xcb\xe9\x87\xd0\xdf\x88\xda\x38\x97\xbd\x6f\xaa\xab\xd6\xd6\x68\x3a\xa9\x23\x7b\x41\x51\xa2\xcf\x30\x4a\x14\x53\x4d\x53\x22\x50\xec\x82\x20\xdd\x57\x19\xbe\x20\x58\x43\xe8\x80\xd7\xa9\xdb\x62\xe6\x0a\x4b\xd0\xc4\xd7\xf7\xf7\x38\x70\xdc\xa1\x7a\x4d\xa2\xc3\x90\x60\x6e\x50\x3d\xb2\x9a\x8d\x23\x7b\x02\x01\x2a\x46\xbe\xa7\x10\x7c\x28\xf2\x77\xef\x37\x8e\x40\xe3\x0d\x3f\xaf\xcd\x13\x1f\xa1\x5d\xdb\xfa\x10\xc9\x2e\x6f\x3d\x61\x85\x4d\xa4\x79\x59\xae\x92\x16\xb9\xd1\xcd\x06\xd5\xb3\x38\x16\x4a\x72\xc5\xed\xf4\x7d\xdf\x06\x96\xd8\x20\xff\xa1\xf9\x97\xd2\xa1\xac\x46\xff\x8a\x03\x8b\xf5\x87\x13\x2f\x10\x8a\x1b\x81\x54\xfa\xc7\x04\x28\xd6\xb0\xbe\xee\xc6\x60\xab\x3a\x90\x89\xf4\x34\xc5\x5c\x2c\xb4\x0e\xdc\xc6\x45\xe2\xd0\x4a\x43\x8d\x35\xf2\xbe\xff\x54\x54\x6f\xa1\x1e\x86\x7c\x7a\x9a\xbd\x7b\x96\x48\xdb\xc0\xf1\x98\x24\xb4\xe2\x33\x32\x7f\xbd\xfc\xd3\xaa\x0e\x5e\xf5\x25\xf0\x96\x05\x6b\x2c\x8c\x78\x39\xee\x7d\xa7\x8b\x99\x98\xc4\x5e\xc8\xbc\x8c\x4b\xf3\xcd\x36\x5c\x0d\x09\x0c\xab\x4b\xf9\x94\xc9\xf7\xf5\x96\x1c\x45\xba\x5e\x9a\xff\x25\x7b\xc9\x2e\xc7\x5d\xf2\xe3\x0e\xf9\x29\xe5\x69\x99\xf3\xf2\xb6\x85\x8b\xb3\x3b\x32\xb6\x32\x6d\x4b\x62\x7f\xed\xd8\xd9\x42\xe7\x40\xd2\x7c\xd7\xe7\xa1\x5c\x65\xc3\x2a\x9b\xa1\x7f\x03\x00\xd5\x6f\x23\xdb\xc1\x03\x00\x00")
+
+func apisPolyfillJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisPolyfillJs,
+		"apis/polyfill.js",
+	)
+}
+
+func apisPolyfillJs() (*asset, error) {
+	bytes, err := apisPolyfillJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/polyfill.js", size: 961, mode: os.FileMode(420), modTime: time.Unix(1792296851, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisUmdJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x6c\x90\xcb\x6a\xc3\x30\x10\x45\xf7\xfa\x8a\x5b\x13\x12\x19\x5c\x7d\x40\x82\xe9\xb2\xbb\x6e\xba\xf0\xa2\x74\xe1\xc7\xa8\x55\xb0\x25\x23\x8d\xa1\x41\xe8\xdf\x8b\x5f\x24\x85\x6e\x8f\xee\x3d\x33\x23\xa9\x27\xdb\xb2\x71\x16\xd2\x3b\xc7\x05\x74\xdd\xb2\xf3\xb7\x1c\x51\x00\x46\x43\xf2\x6d\x24\xa7\xd1\x91\x36\x96\x50\x96\x25\x4e\x7b\xe7\x84\xe3\x71\x7b\x50\xf5\xd0\xad\x1d\x6c\x44\x7e\x7c\xde\x6d\x17\x01\x24\x50\x1f\xe8\xd1\x39\xb8\x6e\xea\x37\xa7\x6b\xae\xd4\xf2\x62\x5c\xb1\xa2\x9f\xd1\x79\x0e\xbb\xf5\x2f\x45\xb9\xbb\xe5\xa3\x7c\x8d\xce\x97\xa8\xaa\x79\xcc\xa8\xaa\x59\x62\x22\xed\xc3\x03\xf5\x1a\x4f\xf3\xe8\xc9\xae\x1b\x77\x27\xbc\xac\xf8\x0c\xfe\x36\xa1\xc0\xfd\x73\xe6\x2d\x62\x64\x1a\xc6\xbe\x66\x42\xe6\x27\xcb\x66\xa0\x0c\x2a\x25\x21\x00\x4f\x3c\x79\xbb\xcd\xaf\x9a\x33\xaa\x26\x46\x5f\xdb\x2f\xc2\xc1\x14\x38\x38\x9c\x4b\xa8\xf7\xd6\x9b\x91\x43\x4a\xc5\x92\xcb\x62\xbc\x06\x1c\x9c\x7a\x25\x7e\xab\x07\x4a\x29\x9b\x8b\xf2\x1f\x9e\x2f\x85\x18\x9f\x41\xb6\x4b\x69\x3e\xe5\x22\x52\x9e\x5f\xc4\xef\x00\x7e\x3a\xdb\x00\xc2\x01\x00\x00")
+
+func apisUmdJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisUmdJs,
+		"apis/umd.js",
+	)
+}
+
+func apisUmdJs() (*asset, error) {
+	bytes, err := apisUmdJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/umd.js", size: 450, mode: os.FileMode(420), modTime: time.Unix(1792297695, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _apisWbJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xb4\x39\x6b\x73\xe3\x36\x92\x9f\xa5\x5f\xd1\xd6\xf9\x22\x32\xa6\x29\xcf\xdd\xd5\xd5\xc5\x3a\xdd\xd4\xe4\x75\xb5\xbb\x53\xc9\x54\x9c\xad\xf9\x20\x6b\x5c\x10\xd9\x14\x91\xa1\x00\x06\x80\x64\x2b\x1a\xfe\xf7\xad\x6e\x80\x0f\xf9\x31\xc9\xa6\x6a\xbf\xd8\x24\xd1\xe8\xf7\x5b\xc7\xa3\x2c\x40\x69\x07\xe9\x37\xe5\x4e\x7d\xb4\x4d\x73\x3c\x96\x58\xd5\x68\x2c\xa4\x37\x99\x91\xb5\xe3\x6f\xa8\x72\xb8\x6c\x9a\x71\xb1\x53\x99\x93\x5a\xc1\xfb\x75\x24\xf3\x18\x8e\xe3\x11\xa3\x48\xbf\xd5\x5b\x21\x95\xbd\xc1\xac\x69\xc6\x23\x59\x40\x74\x96\xf3\xa7\xe8\x78\xf4\x0f\x76\x08\x04\xe9\x9b\xaa\xd2\xf7\x6f\x75\x26\xaa\x52\x5b\xd7\x34\x09\xdc\x4b\x95\xeb\xfb\xb4\xd2\x99\x20\x12\x31\x63\x1f\x65\x5a\x59\x5d\x61\x5a\xe9\x4d\x34\x79\xaf\xf5\xba\x42\x40\x63\xb4\x81\x6b\xf0\x88\xc1\xa0\x75\x46\x66\x0e\xf3\x49\x3c\x1f\x8f\x46\x06\xdd\xce\xa8\xf9\x78\xd4\x10\x77\xa8\xf2\xa6\x19\xd3\xd3\xbd\x74\x25\xa4\x6f\x65\x86\xca\x22\xb1\x39\x9b\xc1\xcf\x25\x42\xe5\xbf\x80\xb4\xb0\x47\x23\x0b\x89\x39\x68\x95\x61\x90\xe3\xfd\x3a\x2d\x85\xfd\xf1\x5e\xbd\x33\xba\x46\xe3\x0e\xd1\xf4\xee\xae\x92\xd9\x34\x8e\xe1\xfd\x3a\xe5\x67\x58\xb4\x58\xa2\xc9\xf1\xf8\x8b\x85\xf4\x67\xfd\x11\x55\xd3\x4c\x12\x98\x1c\x8f\xe9\xdf\xf0\xf0\xad\x70\xa2\x7b\x7f\x53\x6d\xf8\xf9\x91\xcc\x29\xe9\x42\x89\x2d\x26\xf0\xad\x70\x98\x2a\x7d\x1f\xc5\x30\x83\x57\x57\x57\x57\xf1\xdc\xf3\xd3\x92\xfc\x7d\xf5\x4c\xe0\xa2\x63\xf0\x33\x8a\x91\x45\x74\x16\xb9\x52\x5a\x90\xca\x3a\xa1\x32\xd4\x05\xbc\x5f\xb3\xfe\x01\xc2\x25\x50\x78\x1f\x8c\x3e\x1f\x03\x34\xe3\x31\xc0\x5e\x18\xc8\x2c\x2c\x98\x91\xe3\xf1\xbc\x42\x15\x5c\x06\xae\x17\x50\xa1\x1a\xb8\x10\x61\x3a\x1e\x8d\x50\x1b\x84\x73\x99\xc0\xb9\x26\x98\xc1\xf9\x68\x34\xf2\x9a\x3b\xd7\xe9\xff\xa3\xfb\x41\x6c\xb1\x69\x26\xd7\xc1\x6a\x52\xe5\xf8\x00\xe7\xc1\x4b\x4f\x60\x96\xa4\xcf\xef\x65\x45\xe0\x09\x3d\xff\x45\x39\xdc\x18\xe9\x0e\x4d\x33\x59\x1d\x8f\x58\x91\xb1\x8f\x47\x7f\xe7\x46\xef\x4c\x86\xc1\xa5\x9b\x26\x61\xba\x77\x77\xcf\x51\x1e\x8f\x5a\xa9\xde\x09\x23\xb6\x9d\x50\x1e\xcc\x7f\xf3\x8c\x9f\x08\x56\x13\xdc\x53\x18\x92\xee\xbc\x4e\xbf\x97\x58\xe5\x5e\xb0\xf3\x3a\x7d\x2b\x1d\x1a\x51\x11\x3b\x14\x87\x08\x51\x5d\xed\xec\x2b\x38\x97\x31\xf4\x84\x9b\x26\x69\xad\xc5\xc4\xba\xc7\x97\xae\x75\x5a\x1d\xdc\x6b\x9f\x82\xed\x66\x33\xb8\xc9\x4a\xdc\x0a\x0b\xba\x00\x57\x22\xb8\x43\x8d\x39\xd4\x44\x11\x1d\x1a\x9b\xd0\xd7\x03\xec\x45\x25\x73\xe1\x90\x61\xf6\xa2\xda\xa1\x85\x8d\xdc\xa3\x02\xa7\x41\x2a\xe9\x82\x23\xd4\xde\x11\x7e\xc7\xcc\xc1\x9c\x96\x49\xc3\xb9\xfe\x9c\xe1\x53\x6f\x9e\xc0\xf8\x33\xfc\xbb\x43\x85\x60\x4b\x44\xd7\x09\x91\x19\xe4\x40\xb2\x89\x7f\xe4\x48\xa6\x13\x69\xa0\x90\xc6\xba\x21\xcb\xf6\x0f\xb2\x1c\x52\x64\xf4\xa2\x13\xc6\xbd\x60\xcc\xd3\x9f\x90\xeb\x91\x78\x1c\x5a\xb0\x80\xcc\x2e\x65\xbe\xa2\x88\x93\x45\x44\x16\xd2\x05\x7d\x5f\xc0\x74\xa7\x72\x2c\xa4\xc2\x7c\x1a\xe2\xf4\x73\x99\xa0\x55\xcb\x24\x01\x99\x27\x30\x21\x71\x0a\xbd\x53\x9c\x30\x01\x00\x42\x90\x77\x48\xe7\x03\x3d\x3b\x61\x40\x5a\x10\x60\xb1\xc2\xcc\x69\x03\xda\x80\x50\x80\x15\x6e\x51\x91\x32\x29\x79\xa4\xa4\x58\x58\x40\x57\x21\x22\x27\x4c\x02\xb5\xe7\x6e\xc8\x3f\xe1\x23\x09\x28\x69\xab\xcd\x14\x5e\x43\xae\xb3\x1d\xa1\x4a\x7f\xdd\xa1\x39\xdc\x04\x32\x84\x20\x26\x48\xb5\xab\x2a\xb8\x86\x33\x7e\xf7\xd8\x3e\x2b\xed\x77\x9e\xb1\x49\x02\xcc\x42\x2f\x2d\x48\xf6\x85\x8e\x5e\x2b\x7d\x2b\xbf\x7f\xa3\x9c\x48\xa5\xe1\x5d\x17\x0a\x20\x0c\x42\xa6\x6b\x89\x79\xe2\x11\x60\x21\x76\x95\xf3\x07\xb6\x14\x06\x73\x58\x1f\x40\x54\x15\x1f\xb7\x79\xd4\xa6\x1e\xd3\x9b\xc7\xc1\x05\x1f\x11\x6b\x0b\xd2\xd9\x16\x15\xc8\xa2\x8f\x30\xe0\x5c\xcc\x91\x47\x18\xc8\x19\xee\xc8\x55\x9b\x04\x72\xef\x14\xd3\xbb\xbb\xe9\x85\xcc\x57\x09\x58\x72\x93\x9a\xdd\x04\x3e\x7d\x82\x63\x43\x89\xbe\xd0\x06\x22\x8e\x4a\xa3\x6b\x92\x3a\x8f\xe1\x6e\x49\x2f\x2b\x58\x40\xee\x9f\x08\x90\x0a\x4a\xed\x2b\xc9\xd3\x4b\xed\x81\x2f\x83\x77\x8f\xab\x20\x81\xc5\x31\x64\x5a\x39\xa9\x76\x38\xef\x41\x6d\xf6\x02\xac\x47\x37\xea\x59\xa9\x7b\x56\x46\xa3\x06\x28\x57\x03\xf3\x14\x20\xce\xbc\xf5\xbb\x9b\xc4\xde\x9e\x54\xa0\xd1\x64\x18\xd9\xcc\xc3\xa5\xa4\xdf\xa4\xc5\x16\x27\x80\xc6\xc0\xa2\xd5\x61\x07\x96\xc0\x3e\xf6\xa4\x98\x4f\x34\x26\x86\xcf\x39\x52\x67\xaf\x49\xc2\x4a\x49\x60\xa2\x0b\x8e\x21\xb8\x80\xc9\xf5\x84\xe9\xb4\x18\x99\xf7\x5e\xb2\x7d\x90\x69\x1c\xfe\x34\xcf\x99\xc5\x86\x2a\xce\xec\x74\xc2\x18\xfc\x75\x27\xc9\xa5\xbe\xf8\xa2\x47\x78\xaa\x88\x3f\xc7\x75\x02\x13\x69\xa1\x45\x3f\x09\x9c\x77\x5d\x41\xcf\x68\x0d\x0b\xb8\x9b\x8f\x39\x4b\x5d\x92\x41\xba\xf6\x30\xc4\x06\xb5\x4d\x19\x7d\x7a\x9c\x78\xc9\x75\x0b\x74\x59\xe9\x33\x2f\x79\xf8\x20\xef\xb6\xc4\xa0\xd2\x22\x8f\x88\xa1\x2c\x4e\x5d\x89\x2a\xea\xd3\x46\x56\x0e\x74\x92\x95\xa9\x8d\xc1\x7a\xf7\x5e\x00\xbd\x32\xa3\x01\x0d\x53\x45\x82\xf2\x0d\x4e\x13\xcf\x03\xcf\xbe\xea\x8f\xc7\x4f\x40\x7b\x88\xd0\xfe\x8c\x3a\xd2\x1d\xc8\xc0\x26\xf6\xb1\x27\xcb\xbc\xf3\xe3\xa0\x07\xae\x40\x24\x77\x2d\x8c\x0d\xad\x23\x90\xa9\x9f\xa4\x03\x9f\x3c\xc8\x76\xce\xec\x28\xc5\xb5\x68\x0a\x51\x55\x16\xd6\x22\xfb\x48\x4a\x13\xa1\x8c\x84\x04\x0b\x54\x59\xf4\xce\x01\x2b\xd0\xc7\x80\x2d\x61\xe1\x7b\x3b\x6b\x29\xec\xa3\xf6\x99\xb2\x44\x3c\x0c\xc5\xf2\x59\x01\x6c\x19\x34\xca\xcc\x47\x5e\xc1\xe1\x5e\xd6\x62\xf2\x40\xfd\xc7\xcc\x7f\xb5\xdd\x57\x56\x1f\xf3\xe3\x60\xc1\x55\x60\xde\x7e\xb8\xe3\xc6\x70\xe9\xe1\x3a\x0d\x5b\x27\x8c\x8b\x5a\xfd\x31\xdc\x5d\x4e\x80\xff\x64\x69\x78\x53\x55\xbe\x3a\x5c\xc3\xd2\x09\x13\x78\xec\xc2\x4b\xc2\x02\xae\xe6\x20\xe1\x7f\x19\x7f\x5a\xa1\xda\xb8\x72\x0e\xf2\xe2\x22\x26\xce\xd2\x7a\x67\xcb\x88\x3a\xda\x2c\x22\x80\xa5\x5c\x51\xa5\x3a\x89\x07\x82\xeb\x62\x82\x6c\xf4\xb5\xd1\xf7\x16\x8d\xa5\x7c\x9f\xeb\x7b\x1a\x3e\xc0\xee\xea\x5a\x1b\x6f\x21\xa8\x75\x75\x28\x64\x55\xb5\xbe\x73\xd6\xf1\x5e\xa2\xc8\x53\xe1\x9c\xc8\xca\x1b\xbe\x1c\xb7\x05\x97\xcd\xd6\x5e\x8c\x42\x2c\xb0\x96\xe2\xa1\x9f\x13\xab\xef\x8c\xde\x4a\x8b\x83\x48\x31\xad\x22\x4d\x14\x14\xeb\x2f\x79\x07\x18\xb6\x4a\x3e\x5d\x92\xe7\xed\xd1\x38\xae\xe5\xac\xe1\x04\xec\x2e\x2b\x41\x58\x10\x0a\x84\x73\x46\xae\x77\x2e\x14\xa1\x04\x9c\xee\x1a\x43\x0a\x73\xe1\x71\x75\xe9\x65\x0c\x7d\xb1\x0f\xf9\xd8\x51\x86\xf5\xed\x08\x69\x20\x58\x75\x0f\x67\xbd\x4d\x3b\xd1\xf7\xf3\x0e\x8c\x6d\xae\x76\xdb\x35\x9a\x29\x25\xbd\x7d\xea\x8c\xdc\x46\x31\xdf\xeb\x6f\xfc\xc0\x10\xd1\x3e\x7e\x74\x73\xad\x75\x85\x42\xf1\xd5\x68\xcf\x9f\xa6\x14\x14\xfe\xd1\x99\x1d\x0e\x5e\x0b\x51\x59\x9c\xc6\x1d\x52\xcf\x9c\xff\xfa\x08\xaf\x30\x46\x1c\xf8\xaa\x7f\xd7\xeb\x5f\x30\x73\x6d\xbf\x35\x72\xe6\x10\x9e\x5a\x33\xfd\xf5\xe6\xc7\x1f\x52\xce\x02\x2d\x93\xa3\x06\x32\xe1\xb2\x12\x22\x8c\xe1\xc8\x53\x50\x33\x18\xaa\xf6\xc3\x3e\x2b\x54\xab\xc0\x98\x85\xfb\x52\x70\x5a\xb9\x37\x5a\x6d\xbc\x8b\xf5\x1d\x02\xd9\xa3\x37\x45\x02\xda\x90\xd0\xb2\x00\xe9\x02\x36\xeb\x5b\x77\x10\x1b\x9a\xb9\x1d\xa7\xe2\xd0\x77\xdb\xa1\xe9\xba\x22\xd9\xdb\xae\x8d\x68\x1b\xea\xaa\x4a\x40\x72\xad\x63\x99\xec\xbd\x64\x89\x5c\x80\xce\x84\xc5\xce\x7c\xd7\x2c\xf5\x13\xe3\x87\x53\xd2\xe5\x99\xb4\xdf\x53\x31\x20\x1d\x75\x56\x98\xe2\x43\x8d\x34\xc1\x83\x80\x00\xeb\xf5\xa7\x7c\x29\xa5\xc7\xb5\x41\xf1\x71\xde\x53\x6c\xcd\xfe\x02\xc9\xf6\xf8\x59\x1a\xed\xe1\xf3\x98\x83\xa1\x7b\xc4\xe4\x3a\xa1\x0f\x25\x6f\x18\x92\x09\xb0\xf4\xfd\x0d\xf9\x4b\x2a\x2d\xff\x7f\x41\x3a\x05\xe1\xc2\xf3\x94\xbd\xcb\xf5\x84\xcf\xfe\x18\x4e\x7f\xcd\xa3\xe4\x2c\x18\x32\xa0\x4d\xa5\xc3\xad\xa5\xc0\xa0\x64\xb8\x3f\xcd\x84\xc1\x79\x89\x4e\x74\xda\x30\x1d\x49\xc4\xeb\xf6\x7a\x93\xc0\x7e\x29\x57\xf1\x90\x78\x28\x4d\x53\xb8\x00\x09\x17\x30\xbd\xe6\xc7\xd6\x45\x46\x4d\x6f\xbc\x96\xe6\xb3\xf2\xa2\xda\x6d\xa7\xd7\x4f\xf8\x26\x66\x6d\x4a\x87\x2f\x72\xec\x8f\x97\x72\xc5\xa6\xd9\xf7\xac\x4d\x87\x1c\x3c\x51\x96\x56\x1c\x3b\xc4\x2c\x47\xab\xcf\x4a\xb2\x38\x04\x84\x71\x6a\x69\x91\x13\xbd\x4a\xe0\xf2\x95\x8f\xe1\xd0\xa1\xbf\xe0\x66\x8f\xd3\xda\xd0\xcb\xc2\xd9\xbc\xbf\xc9\x79\x24\xd3\x95\xf6\x89\xee\x6c\xf6\x21\xfa\xb7\x68\x79\x75\xf9\x95\xb8\x2c\x56\xc7\xff\x4c\xfe\xab\xf9\xd4\xbd\xfd\xf7\xe0\xf9\x7f\x9a\xf8\x53\x64\x36\xeb\x4f\xa5\xad\x62\xf1\xfa\x36\xdc\xf9\x0d\xd2\xe4\xdf\x6f\x67\x17\x97\xab\x2f\x6f\xe3\x4f\x4b\x71\xf9\xdb\xea\x22\x3e\x9f\xc9\xd4\xa1\x75\x2f\xc5\x97\x27\xff\x98\xa9\x9d\xa9\xba\xcc\xc6\x09\x60\x0b\x0b\x98\x7d\x88\x18\x29\xfd\xb9\xba\xfc\xea\x22\xbd\x5c\x7d\x19\x5f\xcf\x64\x8a\x0f\x98\x45\xfb\xd4\x60\x5d\x89\x0c\xa3\xd9\xf2\xd6\xdd\xaa\x5b\xb3\x9a\x6d\x12\xca\xd8\xfd\xc1\x87\xe5\xed\xc3\xd5\xd5\xe5\xed\xc3\x7f\x5c\xad\x2e\x66\x7c\x18\x32\x23\xd3\xde\x06\x2d\x94\xce\xd5\xf6\x75\xc7\xf9\x76\xf9\x6a\xf5\x3c\xf3\x06\x2b\xe1\xe4\x1e\x13\xa0\x2b\xa0\x0d\xff\xb7\xf0\xf7\x9f\xde\x9e\x18\xde\xbb\x48\x2d\x9c\x43\xa3\x98\x08\xd5\xd0\x9f\x70\xf3\xdd\x43\x1d\x4d\x3f\x44\xaf\xaf\xc9\x05\x7a\x88\x0b\x98\xc6\xe7\xd3\xf8\x33\x8a\x73\x1a\xb6\x9c\xca\x4f\x2e\xce\x9f\x77\xf4\xa6\x4d\xa3\x6b\x58\xc0\x69\x7d\x7b\x4d\x89\xfa\x1a\xa6\x02\x3c\x3c\xbb\x63\x57\x79\x6c\xba\x95\xaa\x1d\x7b\x88\x6f\xc5\xa1\xb0\x95\xea\x19\x96\x88\x93\x35\xb1\x2e\x1c\x54\x28\xac\x0b\xbc\x6d\xa5\x1a\x22\x14\x0f\xa7\x08\xff\x0f\xf8\xe3\xef\x20\xdc\xea\x1e\x9f\x78\x98\x0f\xaa\xd6\x74\x3a\x2c\x5b\xbe\xfd\x6d\x8b\x96\xe8\xdb\x5b\x41\x43\x89\xed\x97\x34\x5c\xa4\x98\x0b\x3f\xeb\x1e\x78\x7a\x56\x3a\x14\xac\xd0\x4b\x61\x3e\x2c\x4e\x7c\x31\xca\xac\x7d\xda\x55\x7c\x73\x73\xc3\x1b\xa0\x1b\xa6\xcf\x45\x3c\xdc\xf2\x65\x26\x9a\x8a\x5c\xd7\x0e\xf3\x1e\xca\x4e\x79\x16\x0e\x6d\x59\x6f\x64\x62\x8a\x05\xec\x4b\x3a\x37\xd9\xb0\xe0\xce\xeb\x84\x52\x14\xdc\xd7\xb6\x3e\x7e\x73\x50\x19\x73\x38\x1f\x66\x1c\xcb\x6f\x27\xd5\x7f\x78\xdc\x51\xec\xba\xb4\xa7\x83\x16\x6b\x85\x06\xa5\x30\x50\x59\x3f\x3d\xb4\x53\x97\xe8\x67\x2e\x9a\x38\x12\xc8\x4a\xde\x1b\xf0\xc4\x55\x21\x08\xde\x77\x84\x55\x68\xea\xb1\xf5\x63\x9b\xc1\x8d\xb4\xbc\xdb\x90\xce\x62\x55\x90\x62\xee\xee\xee\xd7\x9e\x3c\x2f\x82\x4c\x8f\x8b\x56\xd2\xe9\xd0\x2e\xfd\xfc\x56\x0e\x7a\x86\xca\xb6\x53\x49\x56\xf6\x53\x49\x56\xb6\x53\x49\x30\xe0\x59\xf5\xfc\x54\x55\xb5\x63\xde\x0b\xed\x6e\x02\x9d\x1a\x5b\xf3\x74\x2d\xb6\x9f\xdd\xc2\xe6\x27\x9a\x5a\xde\xde\x4d\x3b\x5b\x59\x93\x79\xd6\xd6\x54\x76\x2e\x20\x2b\x97\x57\xab\xf6\xb0\xd3\x12\x4f\x98\xcb\x57\xdd\x41\x66\xb4\xb5\x3f\x1a\xb9\x91\x14\xe0\x53\xa1\xb4\x3a\x6c\xf5\xce\x4e\x5b\x00\xad\xd8\x3e\xc3\xad\xd7\x49\x0e\xe5\x21\x28\xec\xf8\x07\xda\xe5\x3d\x4d\x02\x85\xdf\xe5\x30\x2f\x81\xe6\x28\xc7\x0a\x1d\x3e\xf9\x3c\xf0\xfb\xc2\xb7\xb1\xad\xaf\x77\x4e\x6c\xa2\x22\x8a\xe3\x53\x34\x55\xb7\x3e\x1c\x8d\x46\xc8\x13\xcf\x77\xc6\x68\xf3\x74\x41\xc8\x4e\x31\x69\x35\x43\xeb\x0d\xf2\x31\xfa\xe0\x97\x1d\x83\x95\xd4\xa4\xa5\xd2\xf4\x6a\xf0\x78\x9e\xd5\x03\xef\xbf\x0a\x21\x2b\xcc\x03\x99\xc1\x8a\x80\x9b\xd3\xb0\xa2\x05\x85\x0f\xdd\x7e\xf6\x5f\x22\x83\xd2\x8e\x3d\x17\x9f\xc8\xf0\x68\x54\xab\x6b\x54\xf9\x37\xa5\xac\xf2\x28\xc4\x75\x70\xdf\x76\x7d\xd1\xf1\xd4\x84\xd0\x0d\xdb\x04\x7a\x79\xfc\x73\xd8\xf1\xe8\x70\x5b\x57\xc2\x21\x4c\xfc\x2f\x56\x93\xe1\xbe\xd7\x5f\xe8\x7e\x95\x1a\x42\x87\x9f\x94\x26\x90\xf6\x17\xc6\xdd\xb2\xd6\xcf\xdb\xcd\x78\x3c\xbc\xd2\xce\x92\x7c\x67\x4c\x9c\xf9\x5f\xbd\xfa\xb4\x32\x9b\x81\x7f\xe1\xf4\xdb\x1a\xa2\x30\x7a\xdb\xc6\x47\xd8\x71\x4a\xc3\xb3\xf6\xa1\x5d\xf1\xb0\xe6\x0c\xed\x39\x43\x47\x34\x0e\xf0\xb0\xf0\x42\x9c\xa7\x5f\x0b\x12\x21\xac\xbd\xfb\x37\xbf\x8c\xe9\x63\x75\x67\x0c\x2a\xe7\x37\xec\xc3\x19\xff\xe4\x80\x62\x76\xd0\x60\x7c\xb8\x9d\xad\xbe\x3c\xf7\x1d\x04\x95\xcf\x69\x50\xc8\x7c\xdc\xab\xff\x1f\x03\x00\xfc\x7e\x23\x8f\xce\x1c\x00\x00")
+
+func apisWbJsBytes() ([]byte, error) {
+	return bindataRead(
+		_apisWbJs,
+		"apis/wb.js",
+	)
+}
+
+func apisWbJs() (*asset, error) {
+	bytes, err := apisWbJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "apis/wb.js", size: 7374, mode: os.FileMode(420), modTime: time.Unix(1792297730, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -140,7 +350,17 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"apis/chunk.js": apisChunkJs,
+	"apis/cjs.js": apisCjsJs,
+	"apis/domain.js": apisDomainJs,
+	"apis/elements.js": apisElementsJs,
+	"apis/esm.js": apisEsmJs,
 	"apis/js2015.js": apisJs2015Js,
+	"apis/jsclass.js": apisJsclassJs,
+	"apis/license.js": apisLicenseJs,
+	"apis/polyfill.js": apisPolyfillJs,
+	"apis/umd.js": apisUmdJs,
+	"apis/wb.js": apisWbJs,
 }
 
 // AssetDir returns the file names below a certain
@@ -184,7 +404,17 @@ type bintree struct {
 }
 var _bintree = &bintree{nil, map[string]*bintree{
 	"apis": &bintree{nil, map[string]*bintree{
+		"chunk.js": &bintree{apisChunkJs, map[string]*bintree{}},
+		"cjs.js": &bintree{apisCjsJs, map[string]*bintree{}},
+		"domain.js": &bintree{apisDomainJs, map[string]*bintree{}},
+		"elements.js": &bintree{apisElementsJs, map[string]*bintree{}},
+		"esm.js": &bintree{apisEsmJs, map[string]*bintree{}},
 		"js2015.js": &bintree{apisJs2015Js, map[string]*bintree{}},
+		"jsclass.js": &bintree{apisJsclassJs, map[string]*bintree{}},
+		"license.js": &bintree{apisLicenseJs, map[string]*bintree{}},
+		"polyfill.js": &bintree{apisPolyfillJs, map[string]*bintree{}},
+		"umd.js": &bintree{apisUmdJs, map[string]*bintree{}},
+		"wb.js": &bintree{apisWbJs, map[string]*bintree{}},
 	}},
 }}
 
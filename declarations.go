@@ -0,0 +1,144 @@
+package wbzr
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/woobleio/wooblizer/engine"
+)
+
+// Declarations returns the TypeScript declarations of the library built by
+// Wrap, in the format of the Wbzr. Wb(id) takes the union of the creation
+// names, and the init of each creation takes its parameters. The output is
+// deterministic, the creations are sorted by name.
+func (wb *Wbzr) Declarations() *bytes.Buffer {
+	scripts := append([]engine.Script(nil), wb.Scripts...)
+	sort.SliceStable(scripts, func(i, j int) bool { return scripts[i].GetName() < scripts[j].GetName() })
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by wooblizer. DO NOT EDIT.\n\n")
+	b.WriteString("declare namespace Wb {\n")
+	b.WriteString("  /** Name of a creation of the library */\n")
+	b.WriteString("  type Name =")
+	if len(scripts) == 0 {
+		b.WriteString(" never")
+	}
+	for i, sc := range scripts {
+		if i > 0 {
+			b.WriteString(" |")
+		}
+		b.WriteString(" " + tsString(sc.GetName()))
+	}
+	b.WriteString(";\n\n")
+	b.WriteString("  /** Instance of a creation, its shadow root is the document */\n")
+	b.WriteString("  interface Creation {\n    document: ShadowRoot;\n    [member: string]: any;\n  }\n\n")
+	b.WriteString("  /** Woobles returned by Wb, by creation name. The target is a selector or an element. */\n")
+	b.WriteString("  interface Woobles {\n")
+	for _, sc := range scripts {
+		writeWooble(&b, sc)
+	}
+	b.WriteString("  }\n}\n\n")
+
+	// Wb returns undefined on a domain it is not secured or licensed for
+	wooble := func(name string) string {
+		if len(wb.DomainsSec) > 0 || wb.License != nil {
+			return "Wb.Woobles[" + name + "] | undefined"
+		}
+		return "Wb.Woobles[" + name + "]"
+	}
+	b.WriteString("declare function Wb<N extends Wb.Name>(id: N): " + wooble("N") + ";\n")
+
+	switch wb.Format {
+	case ESModule:
+		b.WriteString("\nexport default Wb;\nexport { Wb };\n")
+		for _, sc := range scripts {
+			b.WriteString("export declare var " + jsIdent(sc.GetName()) + ": " + wooble(tsString(sc.GetName())) + ";\n")
+		}
+	case CommonJS, UMD:
+		b.WriteString("\ndeclare const lib: {\n  Wb: typeof Wb;\n")
+		for _, sc := range scripts {
+			b.WriteString("  " + tsString(sc.GetName()) + ": " + wooble(tsString(sc.GetName())) + ";\n")
+		}
+		b.WriteString("};\nexport = lib;\n")
+	case CustomElements:
+		b.WriteString("\ninterface HTMLElementTagNameMap {\n")
+		for _, sc := range scripts {
+			b.WriteString("  " + tsString(elementName(sc.GetName())) + ": HTMLElement & { wooble?: Promise<Wb.Creation>; creation?: Wb.Creation };\n")
+		}
+		b.WriteString("}\n")
+	}
+	return &b
+}
+
+// writeWooble writes the Wooble of a creation, its parameters are optional
+// unless one of them is required.
+func writeWooble(b *bytes.Buffer, sc engine.Script) {
+	var fields strings.Builder
+	optional := "?"
+	for _, p := range sc.GetParams() {
+		jp, ok := p.(engine.JSParam)
+		if !ok {
+			continue
+		}
+		if jp.Type != "" && jp.Default != nil {
+			if l, err := jp.Literal(); err == nil {
+				fields.WriteString("        /** @default " + strings.Replace(l, "*/", "*\\/", -1) + " */\n")
+			}
+		}
+		fields.WriteString("        " + tsString(jp.Field))
+		if jp.Required {
+			optional = ""
+		} else {
+			fields.WriteString("?")
+		}
+		fields.WriteString(": " + tsType(jp) + ";\n")
+	}
+	params := "{}"
+	if fields.Len() > 0 {
+		params = "{\n" + fields.String() + "      }"
+	}
+	b.WriteString("    " + tsString(sc.GetName()) + ": {\n")
+	b.WriteString("      init(target: string | Element, params" + optional + ": " + params + "): Promise<Creation[]> | undefined;\n")
+	b.WriteString("    };\n")
+}
+
+// tsType returns the TypeScript type of the values of a parameter.
+func tsType(p engine.JSParam) string {
+	switch p.Type {
+	case "":
+		return "any"
+	case engine.ParamEnum:
+		var values []string
+		if p.Rules != nil {
+			for _, v := range p.Rules.Enum {
+				if l, err := json.Marshal(v); err == nil {
+					values = append(values, string(l))
+				}
+			}
+		}
+		if len(values) == 0 {
+			return "never"
+		}
+		return strings.Join(values, " | ")
+	case engine.ParamArray:
+		if p.Rules != nil && p.Rules.Items != "" {
+			return tsType(engine.JSParam{Type: p.Rules.Items}) + "[]"
+		}
+		return "any[]"
+	case engine.ParamObject:
+		return "{ [key: string]: any }"
+	case engine.ParamNumber:
+		return "number"
+	case engine.ParamBoolean:
+		return "boolean"
+	}
+	return "string"
+}
+
+// tsString quotes a string as a TypeScript string literal.
+func tsString(s string) string {
+	l, _ := json.Marshal(s)
+	return string(l)
+}
@@ -0,0 +1,141 @@
+package wbzr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// domain is a domain pattern of Secure, as the runtime matches it.
+type domain struct {
+	Host string `json:"h,omitempty"` // ASCII host name
+	Sub  bool   `json:"s,omitempty"` // matches the subdomains of Host, not Host
+	IP   []int  `json:"ip,omitempty"`
+	Bits int    `json:"b,omitempty"` // prefix length of IP
+	Port int    `json:"p,omitempty"` // 0 for any port
+}
+
+// localhost are the domains served with AllowLocalhost: localhost and its
+// subdomains, 127.0.0.0/8 and [::1]
+var localhost = []domain{
+	{Host: "localhost"},
+	{Host: "localhost", Sub: true},
+	{IP: []int{127, 0, 0, 0}, Bits: 8},
+	{IP: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, Bits: 128},
+}
+
+// parseDomain parses a domain pattern: a host name, a wildcard *.host, an IP
+// address or a CIDR range, IPv6 in brackets, each followed by an optional
+// :port.
+func parseDomain(pattern string) (d domain, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("%w %q: %s", ErrDomainPattern, pattern, err)
+		}
+	}()
+
+	host := pattern
+	if strings.Contains(host, "://") {
+		return d, fmt.Errorf("not a host, drop the scheme")
+	}
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && i > strings.LastIndexByte(host, ']') {
+		if d.Port, err = strconv.Atoi(host[i+1:]); err != nil || d.Port < 1 || d.Port > 65535 {
+			return d, fmt.Errorf("bad port %q", host[i+1:])
+		}
+		host = host[:i]
+	}
+
+	addr := strings.SplitN(host, "/", 2)[0]
+	switch {
+	case strings.HasPrefix(host, "["):
+		if !strings.HasSuffix(addr, "]") {
+			return d, fmt.Errorf("unclosed IPv6 bracket")
+		}
+		return d, d.parseIP(strings.Replace(host[1:], "]", "", 1), true)
+	case net.ParseIP(addr) != nil:
+		return d, d.parseIP(host, false)
+	case strings.ContainsAny(host, "/?#@\\"):
+		return d, fmt.Errorf("not a host, drop the path")
+	}
+
+	if d.Sub = strings.HasPrefix(host, "*."); d.Sub {
+		host = host[2:]
+	}
+	if host, err = idna.Lookup.ToASCII(strings.TrimSuffix(host, ".")); err != nil {
+		return d, err
+	}
+	labels := strings.Split(host, ".")
+	for _, l := range labels {
+		if l == "" || len(l) > 63 {
+			return d, fmt.Errorf("bad label %q", l)
+		}
+	}
+	if len(host) > 253 {
+		return d, fmt.Errorf("host name too long")
+	}
+	if _, err := strconv.Atoi(labels[len(labels)-1]); err == nil {
+		return d, fmt.Errorf("neither an IP address nor a host name")
+	}
+	if d.Sub && len(labels) < 2 {
+		return d, fmt.Errorf("wildcard on a top-level domain")
+	}
+	d.Host = host
+	return d, nil
+}
+
+// parseIP parses an IP address or a CIDR range, IPv6 if v6.
+func (d *domain) parseIP(s string, v6 bool) error {
+	addr, bits := s, -1
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		var err error
+		addr = s[:i]
+		if bits, err = strconv.Atoi(s[i+1:]); err != nil {
+			return fmt.Errorf("bad prefix length %q", s[i+1:])
+		}
+	}
+	ip := net.ParseIP(addr)
+	switch {
+	case ip == nil:
+		return fmt.Errorf("bad IP address %q", addr)
+	case v6 && strings.Contains(addr, "."):
+		// The browsers serialize the IPv4 suffix of an IPv6 address in hex
+		return fmt.Errorf("IPv6 address with an IPv4 suffix")
+	case v6:
+		ip = ip.To16()
+	case ip.To4() == nil:
+		return fmt.Errorf("IPv6 address without brackets")
+	default:
+		ip = ip.To4()
+	}
+	if bits == -1 {
+		bits = 8 * len(ip)
+	} else if bits < 0 || bits > 8*len(ip) {
+		return fmt.Errorf("bad prefix length %d", bits)
+	}
+	d.Bits = bits
+	for _, b := range ip.Mask(net.CIDRMask(bits, 8*len(ip))) {
+		d.IP = append(d.IP, int(b))
+	}
+	return nil
+}
+
+// scriptDomains returns the domains the runtime serves, in JSON.
+func scriptDomains(patterns []string, allowLocalhost bool) (string, error) {
+	var ds []domain
+	if allowLocalhost {
+		ds = append(ds, localhost...)
+	}
+	for _, p := range patterns {
+		d, err := parseDomain(p)
+		if err != nil {
+			return "", err
+		}
+		ds = append(ds, d)
+	}
+	b, err := json.Marshal(ds)
+	return string(b), err
+}
@@ -0,0 +1,271 @@
+package engine
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	h "golang.org/x/net/html"
+
+	"github.com/woobleio/wooblizer/engine/css"
+)
+
+// Parameters are bound in the markup with placeholders, {{title}} in texts
+// and attribute values, and in the style sheet with the custom properties
+// --param-color. They resolve at mount time from the parameters given to the
+// constructor.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_$][\w$]*)\s*\}\}`)
+
+const paramProperty = "--param-"
+
+// Variables of the bindings in the prologue
+const (
+	paramsVar string = "__p" // The parameters given to the constructor
+	valueVar  string = "__v" // Returns a parameter as a string, '' if null
+	urlVar    string = "__u" // Empties a URL whose scheme the policy does not allow
+)
+
+// binding is a parameter bound in the markup or the style sheet of a
+// creation.
+type binding struct {
+	name   string
+	source string // HTML or CSS
+	pos    Position
+}
+
+// bindings returns the parameters bound in the markup read into frag, and in
+// a style sheet. The placeholders of frag are located in srcHTML, the markup
+// before it was read, they have no position if they are not found.
+func bindings(frag *h.Node, srcHTML string, srcCSS string) []binding {
+	var binds []binding
+	located := htmlPlaceholders(srcHTML)
+	for _, name := range boundNames(frag, nil) {
+		b := binding{name: name, source: "HTML"}
+		// The placeholders of the comments and of the stripped markup are
+		// skipped
+		for i, l := range located {
+			if l.name == name {
+				b.pos = l.pos
+				located = located[i+1:]
+				break
+			}
+		}
+		binds = append(binds, b)
+	}
+	toks, _ := css.Tokenize(srcCSS)
+	for _, tok := range styleBindings(toks) {
+		binds = append(binds, binding{tok.Raw[len(paramProperty):], "CSS", offsetPosition(srcCSS, tok.Start)})
+	}
+	return binds
+}
+
+// boundNames appends the parameters bound in the texts and the attribute
+// values of a tree to names, in the document order.
+func boundNames(n *h.Node, names []string) []string {
+	var texts []string
+	switch n.Type {
+	case h.TextNode:
+		texts = append(texts, n.Data)
+	case h.ElementNode:
+		for _, a := range n.Attr {
+			texts = append(texts, a.Val)
+		}
+	}
+	for _, text := range texts {
+		for _, m := range placeholder.FindAllStringSubmatch(text, -1) {
+			names = append(names, m[1])
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		names = boundNames(c, names)
+	}
+	return names
+}
+
+// htmlPlaceholders returns the placeholders of the texts and the tags of a
+// markup, with their position.
+func htmlPlaceholders(src string) []binding {
+	var binds []binding
+	z := h.NewTokenizer(strings.NewReader(src))
+	offset := 0
+	for {
+		tt := z.Next()
+		if tt == h.ErrorToken {
+			return binds
+		}
+		raw := string(z.Raw())
+		if tt == h.TextToken || tt == h.StartTagToken || tt == h.SelfClosingTagToken {
+			for _, m := range placeholder.FindAllStringSubmatchIndex(raw, -1) {
+				binds = append(binds, binding{raw[m[2]:m[3]], "HTML", offsetPosition(src, offset+m[0])})
+			}
+		}
+		offset += len(raw)
+	}
+}
+
+// styleBindings returns the custom properties of the parameters used by the
+// var() functions.
+func styleBindings(toks []css.Token) []css.Token {
+	var props []css.Token
+	for i, tok := range toks {
+		if tok.Kind != css.Function || !strings.EqualFold(tok.Raw, "var(") {
+			continue
+		}
+		for _, arg := range toks[i+1:] {
+			if arg.Kind == css.Whitespace || arg.Kind == css.Comment {
+				continue
+			}
+			if arg.Kind == css.Ident && strings.HasPrefix(arg.Raw, paramProperty) && len(arg.Raw) > len(paramProperty) {
+				props = append(props, arg)
+			}
+			break
+		}
+	}
+	return props
+}
+
+// unknownBindings reports the bound parameters which are not parameters of
+// the creation.
+func unknownBindings(name string, binds []binding, params []JSParam) []*Diagnostic {
+	known := make(map[string]bool, len(params))
+	for _, p := range params {
+		known[p.Field] = true
+	}
+	var diags []*Diagnostic
+	for _, b := range binds {
+		if known[b.name] {
+			continue
+		}
+		d := NewDiagnostic(CodePlaceholder, ErrUnknownPlaceholder)
+		d.Message = "unknown parameter " + b.name + " bound in the " + b.source
+		d.Name = name
+		if b.pos.Line > 0 {
+			end := b.pos
+			end.Offset++
+			end.Column++
+			d.Range = Range{b.pos, end}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+func offsetPosition(src string, offset int) Position {
+	line := 1 + strings.Count(src[:offset], "\n")
+	return Position{offset, line, offset - strings.LastIndex(src[:offset], "\n")}
+}
+
+// hasPlaceholders reports whether a placeholder is in the texts or the
+// attribute values of a tree.
+func hasPlaceholders(n *h.Node) bool {
+	if n.Type == h.TextNode && placeholder.MatchString(n.Data) {
+		return true
+	}
+	for _, a := range n.Attr {
+		if placeholder.MatchString(a.Val) {
+			return true
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if hasPlaceholders(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// bindString returns a JavaScript expression of a text, its placeholders
+// read the parameters.
+// | Example |
+// text: Hello {{name}}!
+// => 'Hello ' + __v('name') + '!'
+func bindString(text string) string {
+	matches := placeholder.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return jsString(text)
+	}
+	var parts []string
+	last := 0
+	for _, m := range matches {
+		if m[0] > last {
+			parts = append(parts, jsString(text[last:m[0]]))
+		}
+		parts = append(parts, valueVar+"("+jsString(text[m[2]:m[3]])+")")
+		last = m[1]
+	}
+	if last < len(text) {
+		parts = append(parts, jsString(text[last:]))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// params declares the parameters and the function reading them. With a
+// policy, the URL attributes with placeholders are only known at mount time,
+// so the function checking their scheme is declared too.
+// | Example |
+// => var __p = arguments[1] || {}, __v = function (k) { ... };
+func (jsw *jsWriter) params() {
+	expr := "arguments[1] || {}, " + valueVar +
+		" = function (k) { var v = " + paramsVar + "[k]; return v == null ? '' : String(v); }"
+	if jsw.policy != nil {
+		// The scheme is read as doc.Policy does
+		expr += ", " + urlVar + " = function (v) { var m = /^([^:\\/?#]+):/.exec(v.replace(/[\\x00-\\x20\\x7f]/g, '')); " +
+			"return m && !" + jsSet(jsw.policy.URLSchemes) + ".hasOwnProperty(m[1].toLowerCase()) ? '' : v; }"
+	}
+	jsw.affectVar(paramsVar, expr)
+}
+
+// bindAttr returns a JavaScript expression of an attribute value, a URL with
+// placeholders is checked at mount time if there is a policy.
+func (jsw *jsWriter) bindAttr(key string, val string) string {
+	expr := bindString(val)
+	if jsw.policy != nil && jsw.policy.URLAttributes[key] && placeholder.MatchString(val) {
+		expr = urlVar + "(" + expr + ")"
+	}
+	return expr
+}
+
+// jsSet returns a JavaScript object of the members of a set.
+func jsSet(set map[string]bool) string {
+	keys := make(map[string]int, len(set))
+	for k, ok := range set {
+		if ok {
+			keys[k] = 1
+		}
+	}
+	b, _ := json.Marshal(keys)
+	return string(b)
+}
+
+// bindTemplate replaces the placeholders of the nodes cloned from a
+// template, frag is the variable of the clone. With a policy, the URL
+// attributes with placeholders are checked.
+func (jsw *jsWriter) bindTemplate(frag string) {
+	jsw.bf.WriteString("var __r = function (s) { return s.indexOf('{{') < 0 ? s : s.replace(/\\{\\{\\s*([A-Za-z_$][\\w$]*)\\s*\\}\\}/g, function (m, k) { return " + valueVar + "(k); }); };")
+	jsw.bf.WriteString("var __w = document.createTreeWalker(" + frag + ", 5), __n, __i;")
+	bind := "__n.attributes[__i].value = __r(__n.attributes[__i].value);"
+	if jsw.policy != nil {
+		jsw.bf.WriteString("var __ua = " + jsSet(jsw.policy.URLAttributes) + ", __a;")
+		bind = "{ __a = __n.attributes[__i]; if (__a.value.indexOf('{{') >= 0) __a.value = __ua.hasOwnProperty(__a.name) ? " +
+			urlVar + "(__r(__a.value)) : __r(__a.value); }"
+	}
+	jsw.bf.WriteString("while ((__n = __w.nextNode())) { if (__n.nodeType == 3) __n.data = __r(__n.data); " +
+		"else for (__i = 0; __i < __n.attributes.length; __i++) " + bind + " }")
+}
+
+// bindStyle sets the custom properties of the parameters on the host
+// element, they are inherited by the shadow tree.
+// | Example |
+// => if (__p['color'] != null) _t_.style.setProperty('--param-color', __v('color'));
+func (jsw *jsWriter) bindStyle(host string, props []css.Token) {
+	seen := make(map[string]bool)
+	for _, prop := range props {
+		if seen[prop.Raw] {
+			continue
+		}
+		seen[prop.Raw] = true
+		field := jsString(prop.Raw[len(paramProperty):])
+		jsw.bf.WriteString("if (" + paramsVar + "[" + field + "] != null) " + host + ".style.setProperty(" +
+			jsString(prop.Raw) + ", " + valueVar + "(" + field + "));")
+	}
+}
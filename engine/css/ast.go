@@ -0,0 +1,93 @@
+// Package css parses style sheets with nested rules and compile-time
+// variables, and compiles them into plain minified CSS.
+package css
+
+// Node is a statement of a style sheet: a rule, an at-rule, a declaration or
+// a variable definition. Pos is its byte offset in the parsed source.
+type Node interface {
+	Pos() int
+}
+
+// Sheet is the root of a parsed style sheet.
+type Sheet struct {
+	Rules []Node
+
+	src   string
+	lines []int
+}
+
+// Position converts a byte offset of the sheet source into a 1-based line
+// and column.
+func (s *Sheet) Position(offset int) (line int, column int) {
+	return position(s.lines, offset)
+}
+
+type (
+	// Rule is a style rule, its body holds declarations and nested rules
+	Rule struct {
+		Prelude []Token // the selectors
+		Body    []Node
+	}
+
+	// AtRule is an at-rule such as @media, Block is false for the
+	// statements such as @import
+	AtRule struct {
+		Name    Token
+		Prelude []Token
+		Block   bool
+		Body    []Node
+	}
+
+	// Decl is a property declaration
+	Decl struct {
+		Property Token
+		Value    []Token
+	}
+
+	// VarDecl defines a compile-time variable, $name: value
+	VarDecl struct {
+		Name  Token
+		Value []Token
+	}
+)
+
+// Pos returns the offset of the first selector token.
+func (r *Rule) Pos() int { return r.Prelude[0].Start }
+
+// Pos returns the offset of the at-keyword.
+func (r *AtRule) Pos() int { return r.Name.Start }
+
+// Pos returns the offset of the property.
+func (d *Decl) Pos() int { return d.Property.Start }
+
+// Pos returns the offset of the variable.
+func (d *VarDecl) Pos() int { return d.Name.Start }
+
+func lineOffsets(src string) []int {
+	lines := []int{0}
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '\r':
+			if i+1 < len(src) && src[i+1] == '\n' {
+				i++
+			}
+			lines = append(lines, i+1)
+		case '\n', '\f':
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
+
+func position(lines []int, offset int) (int, int) {
+	i, j := 0, len(lines)
+	for i < j {
+		h := (i + j) / 2
+		if lines[h] <= offset {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i, offset - lines[i-1] + 1
+}
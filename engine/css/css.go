@@ -0,0 +1,309 @@
+package css
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Compile parses a style sheet and returns it as plain minified CSS: the
+// variables are replaced by their values, the nested rules are flattened
+// and the comments and the white spaces which are not needed are removed.
+//
+//	$accent: #c00;
+//	.card { color: $accent; &:hover { color: red } .title { margin: 0 } }
+//
+// compiles to
+//
+//	.card{color:#c00}.card:hover{color:red}.card .title{margin:0}
+func Compile(src string) (out string, err error) {
+	sheet, err := Parse(src)
+	if err != nil {
+		return "", err
+	}
+	c := &compiler{}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bail)
+			if !ok {
+				panic(r)
+			}
+			b.err.Line, b.err.Column = sheet.Position(b.err.Offset)
+			out, err = "", b.err
+		}
+	}()
+
+	c.body(sheet.Rules, nil, &scope{})
+	return c.out.String(), nil
+}
+
+// scope holds the variables defined in a block.
+type scope struct {
+	vars   map[string][]Token
+	parent *scope
+}
+
+func (s *scope) lookup(name string) ([]Token, bool) {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func (s *scope) define(name string, value []Token) {
+	if s.vars == nil {
+		s.vars = make(map[string][]Token)
+	}
+	s.vars[name] = value
+}
+
+type compiler struct {
+	out bytes.Buffer
+}
+
+// conditional are the at-rules which are dropped when their body is empty.
+var conditional = map[string]bool{"media": true, "supports": true, "container": true, "document": true}
+
+// isolated are the at-rules whose body is not nested in the enclosing
+// style rule.
+var isolated = map[string]bool{
+	"keyframes": true, "font-face": true, "page": true, "counter-style": true,
+	"property": true, "font-feature-values": true, "font-palette-values": true,
+}
+
+// body writes the statements of a block. sels are the selectors of the
+// enclosing style rule, nil at the top level; the declarations are written
+// in rules of these selectors. A run of declarations following a nested rule
+// gets its own rule, so the order of the declarations is kept.
+func (c *compiler) body(nodes []Node, sels []string, parent *scope) {
+	sc := &scope{parent: parent}
+	var decls []string
+	flush := func() {
+		if len(decls) == 0 {
+			return
+		}
+		if sels == nil {
+			c.out.WriteString(strings.Join(decls, ";"))
+		} else {
+			c.out.WriteString(strings.Join(sels, ",") + "{" + strings.Join(decls, ";") + "}")
+		}
+		decls = nil
+	}
+
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case *VarDecl:
+			sc.define(n.Name.Raw, sc.resolve(n.Value))
+		case *Decl:
+			decls = append(decls, n.Property.Raw+":"+minify(sc.resolve(n.Value), false, nil))
+		case *Rule:
+			flush()
+			c.body(n.Body, selectors(sc.resolve(n.Prelude), sels), sc)
+		case *AtRule:
+			flush()
+			c.atRule(n, sels, sc)
+		}
+	}
+	flush()
+}
+
+func (c *compiler) atRule(r *AtRule, sels []string, sc *scope) {
+	head := r.Name.Raw
+	if len(r.Prelude) > 0 {
+		prelude := minify(sc.resolve(r.Prelude), false, nil)
+		if sep := prelude[0]; sep != '(' && sep != '"' && sep != '\'' {
+			head += separator(head)
+		}
+		head += prelude
+	}
+	if !r.Block {
+		c.out.WriteString(head + ";")
+		return
+	}
+	name := strings.ToLower(vendorless(r.Name.Raw[1:]))
+	if isolated[name] {
+		sels = nil
+	}
+
+	start := c.out.Len()
+	c.out.WriteString(head + "{")
+	mark := c.out.Len()
+	c.body(r.Body, sels, sc)
+	if c.out.Len() == mark && conditional[name] {
+		c.out.Truncate(start)
+		return
+	}
+	c.out.WriteString("}")
+}
+
+// vendorless removes the vendor prefix of an at-rule name, -webkit-keyframes
+// becomes keyframes.
+func vendorless(name string) string {
+	if strings.HasPrefix(name, "-") {
+		if i := strings.Index(name[1:], "-"); i >= 0 {
+			return name[i+2:]
+		}
+	}
+	return name
+}
+
+// resolve replaces the variables of the tokens by their values.
+func (s *scope) resolve(toks []Token) []Token {
+	var out []Token
+	for i, tok := range toks {
+		if tok.Kind != Variable {
+			if out != nil {
+				out = append(out, tok)
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make([]Token, 0, len(toks)), toks[:i]...)
+		}
+		value, ok := s.lookup(tok.Raw)
+		if !ok {
+			panic(bail{&SyntaxError{Offset: tok.Start, Msg: "undefined variable " + tok.Raw}})
+		}
+		out = append(out, value...)
+	}
+	if out == nil {
+		return toks
+	}
+	return out
+}
+
+// selectors returns the selectors of a rule nested in the rules of parents,
+// the nesting selector & stands for each of the parents. A selector without
+// & is a descendant of the parents.
+func selectors(prelude []Token, parents []string) []string {
+	var list [][]Token
+	depth, start := 0, 0
+	for i, tok := range prelude {
+		switch tok.Kind {
+		case LParen, LBracket, Function:
+			depth++
+		case RParen, RBracket:
+			depth--
+		case Comma:
+			if depth == 0 {
+				list = append(list, trimSpace(prelude[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	list = append(list, trimSpace(prelude[start:]))
+
+	var sels []string
+	for _, sel := range list {
+		if len(sel) == 0 {
+			panic(bail{&SyntaxError{Offset: prelude[0].Start, Msg: "empty selector"}})
+		}
+		nested := false
+		for _, tok := range sel {
+			nested = nested || tok.is("&")
+		}
+		if parents == nil {
+			if nested {
+				panic(bail{&SyntaxError{Offset: sel[0].Start, Msg: "& outside of a nested rule"}})
+			}
+			sels = append(sels, minify(sel, true, nil))
+			continue
+		}
+		for _, parent := range parents {
+			switch {
+			case nested:
+				sels = append(sels, minify(sel, true, func(i int) string {
+					// & inside a compound selector, .a&, needs a compound
+					// parent
+					if i > 0 && strings.ContainsAny(parent, " >+~,") {
+						return ":is(" + parent + ")"
+					}
+					return parent
+				}))
+			case sel[0].is(">") || sel[0].is("+") || sel[0].is("~"):
+				sels = append(sels, parent+minify(sel, true, nil))
+			default:
+				sels = append(sels, parent+" "+minify(sel, true, nil))
+			}
+		}
+	}
+	return sels
+}
+
+// minify writes tokens without the white spaces which are not needed. In
+// selectors the white spaces around combinators are not needed, a white
+// space alone is the descendant combinator. nesting replaces &, its argument
+// is the index of & in the tokens.
+func minify(toks []Token, selector bool, nesting func(i int) string) string {
+	var b strings.Builder
+	var prev *Token
+	space := false
+	for i := range toks {
+		tok := &toks[i]
+		if tok.Kind == Whitespace {
+			space = prev != nil
+			continue
+		}
+		if space && !tight(prev, tok, selector) {
+			b.WriteString(separator(prev.Raw))
+		}
+		space = false
+		switch {
+		case nesting != nil && tok.is("&"):
+			b.WriteString(nesting(i))
+		case tok.Kind == Number:
+			b.WriteString(number(tok.Raw))
+		default:
+			b.WriteString(tok.Raw)
+		}
+		prev = tok
+	}
+	return b.String()
+}
+
+// separator returns the white space separating raw from the next token. A
+// hexadecimal escape ending raw, \0, reads one white space as its end.
+func separator(raw string) string {
+	i := len(raw)
+	for i > 0 && len(raw)-i < 6 && isHex(raw[i-1]) {
+		i--
+	}
+	if i < len(raw) && i > 0 && raw[i-1] == '\\' {
+		// The backslash must not be escaped itself
+		n := 0
+		for j := i - 1; j >= 0 && raw[j] == '\\'; j-- {
+			n++
+		}
+		if n%2 == 1 {
+			return "  "
+		}
+	}
+	return " "
+}
+
+// tight reports whether the white space between two tokens can be removed.
+func tight(prev *Token, next *Token, selector bool) bool {
+	if prev.Kind == Comma || next.Kind == Comma || prev.Kind == LParen || prev.Kind == Function || next.Kind == RParen {
+		return true
+	}
+	if selector {
+		return isCombinator(prev) || isCombinator(next)
+	}
+	return prev.Kind == Colon || next.Kind == Colon || next.is("!") || prev.is("!")
+}
+
+func isCombinator(tok *Token) bool { return tok.is(">") || tok.is("+") || tok.is("~") }
+
+// number removes the leading zero of a fraction, 0.5em becomes .5em.
+func number(raw string) string {
+	sign := ""
+	if raw[0] == '+' || raw[0] == '-' {
+		sign, raw = raw[:1], raw[1:]
+	}
+	if len(raw) > 2 && raw[0] == '0' && raw[1] == '.' {
+		raw = raw[1:]
+	}
+	return sign + raw
+}
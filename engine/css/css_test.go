@@ -0,0 +1,119 @@
+package css_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/woobleio/wooblizer/engine/css"
+)
+
+func TestTokenize(t *testing.T) {
+	toks, err := css.Tokenize(`a:hover{background:url( x.png ) -1.5em/2 "a\
+b" $c #f00 calc(1px + 2%)}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, tok := range toks {
+		if tok.Kind != css.Whitespace {
+			kinds = append(kinds, tok.Kind.String()+" "+tok.Raw)
+		}
+	}
+	expected := []string{
+		"identifier a", "colon :", "identifier hover", "{ {", "identifier background", "colon :",
+		"url url( x.png )", "number -1.5em", "delimiter /", "number 2", "string \"a\\\nb\"", "variable $c",
+		"hash #f00", "function calc(", "number 1px", "delimiter +", "number 2%", ") )", "} }",
+	}
+	if strings.Join(kinds, "|") != strings.Join(expected, "|") {
+		t.Errorf("Tokenize : Unexpected tokens\n%q\nexpected\n%q", kinds, expected)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	for _, test := range []struct {
+		src, expected string
+	}{
+		// Minification
+		{"div  >  p , a  :hover { color : red ; margin: 0.5em  auto !important; }", "div>p,a :hover{color:red;margin:.5em auto!important}"},
+		{"/* note */ a { width: calc( 100% - 2px ); content: 'x  y' }", "a{width:calc(100% - 2px);content:'x  y'}"},
+		{"a { content: \"multi\\\nline\" }", "a{content:\"multi\\\nline\"}"},
+		{"a{}", ""},
+		{"@media screen and (min-width : 10px) { a { b: c } } @media print {}", "@media screen and (min-width:10px){a{b:c}}"},
+		{"@import url(x.css) ; @font-face { font-family: x; src: url('x.woff') }", "@import url(x.css);@font-face{font-family:x;src:url('x.woff')}"},
+		{"a/**/b { c: d/**/e }", "a b{c:d e}"},
+		// Nesting
+		{".card { color: red; &:hover { color: blue } .title { margin: 0 } > p { x: y } }", ".card{color:red}.card:hover{color:blue}.card .title{margin:0}.card>p{x:y}"},
+		{"a, b { & + &, c { d: e } }", "a+a,b+b,a c,b c{d:e}"},
+		{".a .b { .c & { d: e } &__el { f: g } }", ".c :is(.a .b){d:e}.a .b__el{f:g}"},
+		{"a { b: c; d { e: f } g: h }", "a{b:c}a d{e:f}a{g:h}"},
+		{"a { @media (x) { b: c; d { e: f } } }", "@media(x){a{b:c}a d{e:f}}"},
+		{"a { @keyframes k { from { b: c } } }", "@keyframes k{from{b:c}}"},
+		{".a { .b { .c { d: e } } }", ".a .b .c{d:e}"},
+		// Variables
+		{"$c: #c00; $b: 1px solid $c; a { border: $b; $c: blue; color: $c } b { color: $c }", "a{border:1px solid #c00;color:blue}b{color:#c00}"},
+		{"$bp: 40em; @media (min-width: $bp) { a { b: c } }", "@media(min-width:40em){a{b:c}}"},
+		// var() is left to the browser
+		{"a { color: var(--param-color, red) }", "a{color:var(--param-color,red)}"},
+	} {
+		out, err := css.Compile(test.src)
+		if err != nil {
+			t.Errorf("Compile %q : Unexpected error %s", test.src, err)
+			continue
+		}
+		if out != test.expected {
+			t.Errorf("Compile %q : Got %q, expected %q", test.src, out, test.expected)
+		}
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	for _, test := range []struct {
+		src, expected string
+	}{
+		{"a { color: red", "1:15: unexpected end of input, expected }"},
+		{"a { color: red } }", "1:18: unexpected }"},
+		{"a {\n  color: 'red\n}", "2:10: unterminated string"},
+		{"a {\n  color: ;\n}", "2:3: missing value for color"},
+		{"a { color red; }", "1:14: expected { after the selector"},
+		{"/* open", "1:1: unterminated comment"},
+		{"a { b: c(d; }", "1:8: unclosed c("},
+		{"a { b: c) }", "1:9: unexpected )"},
+		{"a { b: $x }", "1:8: undefined variable $x"},
+		{"a { $x: 1 } b { c: $x }", "1:20: undefined variable $x"},
+		{"& a { b: c }", "1:1: & outside of a nested rule"},
+		{"a, { b: c }", "1:1: empty selector"},
+		{"a { b: url(x y) }", "1:14: bad url, white space inside"},
+	} {
+		_, err := css.Compile(test.src)
+		if err == nil {
+			t.Errorf("Compile %q : Expected an error", test.src)
+			continue
+		}
+		if _, ok := err.(*css.SyntaxError); !ok || err.Error() != test.expected {
+			t.Errorf("Compile %q : Got error %q, expected %q", test.src, err, test.expected)
+		}
+	}
+}
+
+func FuzzCompile(f *testing.F) {
+	for _, seed := range []string{"a{b:c}", ".a{&:hover{b:c}.d{e:f}}", "$x:1;a{b:$x}", "@media(x){a{b:c}}", "a{b:url(x)}", "a/**/b{c:'d\\\ne'}"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		out, err := css.Compile(src)
+		if err != nil {
+			if _, ok := err.(*css.SyntaxError); !ok {
+				t.Fatalf("Compile %q : Unexpected error type %T", src, err)
+			}
+			return
+		}
+		// The output is plain CSS, compiling it again changes nothing
+		again, err := css.Compile(out)
+		if err != nil {
+			t.Fatalf("Compile %q : Output %q does not compile, error %s", src, out, err)
+		}
+		if again != out {
+			t.Errorf("Compile %q : Not idempotent %q then %q", src, out, again)
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package css
+
+import "fmt"
+
+// SyntaxError is returned when a style sheet cannot be parsed.
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the source
+	Offset int
+
+	// Line and Column are 1-based, set by Parse
+	Line   int
+	Column int
+
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
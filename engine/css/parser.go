@@ -0,0 +1,268 @@
+package css
+
+// parser reads the rules of a style sheet from its tokens, the comments are
+// dropped and the white spaces kept.
+type parser struct {
+	toks []Token
+	i    int
+	end  int // offset of the end of input
+}
+
+// bail is used to unwind the parser on the first syntax error.
+type bail struct{ err *SyntaxError }
+
+// Parse parses a style sheet. Rules may be nested in the rules, and
+// variables defined with $name: value. The returned error is a *SyntaxError
+// locating the first error found.
+func Parse(src string) (sheet *Sheet, err error) {
+	sheet = &Sheet{src: src, lines: lineOffsets(src)}
+	toks, err := Tokenize(src)
+	if err != nil {
+		e := err.(*SyntaxError)
+		e.Line, e.Column = sheet.Position(e.Offset)
+		return nil, e
+	}
+	p := &parser{end: len(src)}
+	for i, tok := range toks {
+		if tok.Kind != Comment {
+			p.toks = append(p.toks, tok)
+			continue
+		}
+		// A comment separating two tokens, a/**/b, becomes a white space
+		if i > 0 && i+1 < len(toks) && joins(toks[i-1], toks[i+1]) {
+			p.toks = append(p.toks, Token{Whitespace, " ", tok.Start, tok.End})
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bail)
+			if !ok {
+				panic(r)
+			}
+			b.err.Line, b.err.Column = sheet.Position(b.err.Offset)
+			sheet, err = nil, b.err
+		}
+	}()
+
+	sheet.Rules = p.parseBody(true)
+	return sheet, nil
+}
+
+// joins reports whether two tokens written side by side would be read as
+// one.
+func joins(prev Token, next Token) bool {
+	if prev.Kind == Whitespace || prev.Kind == Comment || next.Kind == Whitespace || next.Kind == Comment {
+		return false
+	}
+	last, first := prev.Raw[len(prev.Raw)-1], next.Raw[0]
+	return (isName(last) || last == '\\') && (isName(first) || first == '\\' || first == '(' || first == '%') ||
+		(last == '/' && first == '*')
+}
+
+func (p *parser) fail(offset int, msg string) {
+	panic(bail{&SyntaxError{Offset: offset, Msg: msg}})
+}
+
+// tok returns the current token, EOF past the end.
+func (p *parser) tok() Token {
+	if p.i >= len(p.toks) {
+		return Token{Kind: EOF, Start: p.end, End: p.end}
+	}
+	return p.toks[p.i]
+}
+
+func (p *parser) skipSpace() {
+	for p.tok().Kind == Whitespace {
+		p.i++
+	}
+}
+
+// parseBody parses the statements of the sheet if top, or of a block up to
+// its closing brace.
+func (p *parser) parseBody(top bool) []Node {
+	var body []Node
+	for {
+		p.skipSpace()
+		switch tok := p.tok(); tok.Kind {
+		case EOF:
+			if !top {
+				p.fail(tok.Start, "unexpected end of input, expected }")
+			}
+			return body
+		case RBrace:
+			if top {
+				p.fail(tok.Start, "unexpected }")
+			}
+			p.i++
+			return body
+		case Semicolon:
+			p.i++
+		case AtKeyword:
+			body = append(body, p.parseAtRule())
+		case Variable:
+			body = append(body, p.parseVarDecl())
+		default:
+			if !top && p.isDecl() {
+				body = append(body, p.parseDecl())
+			} else {
+				body = append(body, p.parseRule())
+			}
+		}
+	}
+}
+
+// isDecl reports whether the current tokens are a declaration, a property
+// followed by a colon and a value ending before any block. Otherwise they
+// are a nested rule such as a:hover { }.
+func (p *parser) isDecl() bool {
+	i := p.i
+	if p.toks[i].Kind != Ident {
+		return false
+	}
+	for i++; i < len(p.toks) && p.toks[i].Kind == Whitespace; i++ {
+	}
+	if i == len(p.toks) || p.toks[i].Kind != Colon {
+		return false
+	}
+	depth := 0
+	for ; i < len(p.toks); i++ {
+		switch p.toks[i].Kind {
+		case LParen, LBracket, Function:
+			depth++
+		case RParen, RBracket:
+			depth--
+		case LBrace:
+			if depth <= 0 {
+				return false
+			}
+		case Semicolon, RBrace:
+			if depth <= 0 {
+				return true
+			}
+		}
+	}
+	return true
+}
+
+// until returns the tokens up to a token of one of the kinds at the top
+// level, white spaces trimmed. The parentheses and brackets must balance.
+func (p *parser) until(kinds ...TokenKind) []Token {
+	var stack []Token
+	start := p.i
+	for {
+		tok := p.tok()
+		if len(stack) == 0 {
+			for _, k := range kinds {
+				if tok.Kind == k {
+					return trimSpace(p.toks[start:p.i])
+				}
+			}
+		}
+		switch tok.Kind {
+		case EOF:
+			if len(stack) > 0 {
+				p.fail(stack[len(stack)-1].Start, "unclosed "+stack[len(stack)-1].Raw)
+			}
+			return trimSpace(p.toks[start:p.i])
+		case LParen, LBracket, Function, LBrace:
+			stack = append(stack, tok)
+		case RParen, RBracket, RBrace:
+			if len(stack) == 0 {
+				p.fail(tok.Start, "unexpected "+tok.Raw)
+			}
+			if open := stack[len(stack)-1]; closer(open) != tok.Kind {
+				p.fail(open.Start, "unclosed "+open.Raw)
+			}
+			stack = stack[:len(stack)-1]
+		}
+		p.i++
+	}
+}
+
+func closer(open Token) TokenKind {
+	switch open.Kind {
+	case LBracket:
+		return RBracket
+	case LBrace:
+		return RBrace
+	}
+	return RParen
+}
+
+func trimSpace(toks []Token) []Token {
+	for len(toks) > 0 && toks[0].Kind == Whitespace {
+		toks = toks[1:]
+	}
+	for len(toks) > 0 && toks[len(toks)-1].Kind == Whitespace {
+		toks = toks[:len(toks)-1]
+	}
+	return toks
+}
+
+func (p *parser) parseRule() *Rule {
+	start := p.tok()
+	prelude := p.until(LBrace, Semicolon, RBrace)
+	if tok := p.tok(); tok.Kind != LBrace {
+		p.fail(tok.Start, "expected { after the selector")
+	}
+	if len(prelude) == 0 {
+		p.fail(start.Start, "missing selector")
+	}
+	p.i++
+	return &Rule{prelude, p.parseBody(false)}
+}
+
+func (p *parser) parseAtRule() *AtRule {
+	r := &AtRule{Name: p.tok()}
+	p.i++
+	r.Prelude = p.until(LBrace, Semicolon, RBrace)
+	switch p.tok().Kind {
+	case LBrace:
+		p.i++
+		r.Block = true
+		r.Body = p.parseBody(false)
+	case Semicolon:
+		p.i++
+	}
+	return r
+}
+
+// value parses the value of the declaration of name, the ending semicolon
+// is consumed.
+func (p *parser) value(name Token) []Token {
+	p.skipSpace()
+	if tok := p.tok(); tok.Kind != Colon {
+		p.fail(tok.Start, "expected : after "+name.Raw)
+	}
+	p.i++
+	value := p.until(Semicolon, RBrace)
+	if p.tok().Kind == Semicolon {
+		p.i++
+	}
+	return value
+}
+
+func (p *parser) parseDecl() *Decl {
+	d := &Decl{Property: p.tok()}
+	p.i++
+	d.Value = p.value(d.Property)
+	if len(d.Value) == 0 && !isCustomProperty(d.Property.Raw) {
+		p.fail(d.Property.Start, "missing value for "+d.Property.Raw)
+	}
+	return d
+}
+
+func (p *parser) parseVarDecl() *VarDecl {
+	d := &VarDecl{Name: p.tok()}
+	p.i++
+	d.Value = p.value(d.Name)
+	if len(d.Value) == 0 {
+		p.fail(d.Name.Start, "missing value for "+d.Name.Raw)
+	}
+	return d
+}
+
+func isCustomProperty(name string) bool {
+	return len(name) > 2 && name[:2] == "--"
+}
@@ -0,0 +1,351 @@
+package css
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenKind is the lexical class of a token.
+type TokenKind int
+
+// Token kinds
+const (
+	EOF TokenKind = iota
+	Whitespace
+	Comment
+	Ident
+	Function // an identifier followed by a parenthesis, url( included
+	AtKeyword
+	Hash
+	String
+	URL
+	Number // numbers, percentages and dimensions
+	Variable
+	Delim
+	Colon
+	Semicolon
+	Comma
+	LBrace
+	RBrace
+	LParen
+	RParen
+	LBracket
+	RBracket
+)
+
+var kindNames = [...]string{
+	EOF:        "end of input",
+	Whitespace: "white space",
+	Comment:    "comment",
+	Ident:      "identifier",
+	Function:   "function",
+	AtKeyword:  "at-keyword",
+	Hash:       "hash",
+	String:     "string",
+	URL:        "url",
+	Number:     "number",
+	Variable:   "variable",
+	Delim:      "delimiter",
+	Colon:      "colon",
+	Semicolon:  "semicolon",
+	Comma:      "comma",
+	LBrace:     "{",
+	RBrace:     "}",
+	LParen:     "(",
+	RParen:     ")",
+	LBracket:   "[",
+	RBracket:   "]",
+}
+
+func (k TokenKind) String() string { return kindNames[k] }
+
+// Token is a lexical token of a style sheet.
+type Token struct {
+	Kind TokenKind
+
+	// Raw is the token as written in the source
+	Raw string
+
+	// Start and End are byte offsets in the source
+	Start int
+	End   int
+}
+
+// is reports whether the token is the delimiter d.
+func (t Token) is(d string) bool { return t.Kind == Delim && t.Raw == d }
+
+var punctuators = map[byte]TokenKind{
+	':': Colon, ';': Semicolon, ',': Comma,
+	'{': LBrace, '}': RBrace, '(': LParen, ')': RParen, '[': LBracket, ']': RBracket,
+}
+
+// Tokenize splits a style sheet into tokens, following CSS Syntax Level 3.
+// Variables, $name, are an extension. The offsets of the errors are set,
+// their line and column are not.
+func Tokenize(src string) ([]Token, error) {
+	l := lexer{src: src}
+	toks := make([]Token, 0, len(src)/4)
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok.Kind == EOF {
+			return toks, nil
+		}
+		toks = append(toks, tok)
+	}
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isName(c byte) bool { return isNameStart(c) || isDigit(c) || c == '-' }
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isNewline(c byte) bool { return c == '\n' || c == '\r' || c == '\f' }
+
+// at returns the byte at pos+off, or 0 past the end.
+func (l *lexer) at(off int) byte {
+	if l.pos+off >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos+off]
+}
+
+// validEscape reports whether a backslash at pos+off starts an escape.
+func (l *lexer) validEscape(off int) bool {
+	return l.at(off) == '\\' && l.pos+off+1 < len(l.src) && !isNewline(l.at(off+1))
+}
+
+// startsIdent reports whether an identifier starts at pos+off.
+func (l *lexer) startsIdent(off int) bool {
+	switch c := l.at(off); {
+	case c == '-':
+		return isNameStart(l.at(off+1)) || l.at(off+1) == '-' || l.validEscape(off+1)
+	case c == '\\':
+		return l.validEscape(off)
+	default:
+		return l.pos+off < len(l.src) && isNameStart(c)
+	}
+}
+
+// startsNumber reports whether a number starts at pos.
+func (l *lexer) startsNumber() bool {
+	c := l.at(0)
+	if c == '+' || c == '-' {
+		return isDigit(l.at(1)) || (l.at(1) == '.' && isDigit(l.at(2)))
+	}
+	return isDigit(c) || (c == '.' && isDigit(l.at(1)))
+}
+
+func (l *lexer) next() (Token, error) {
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return Token{Kind: EOF, Start: start, End: start}, nil
+	}
+	tok := func(kind TokenKind) (Token, error) {
+		return Token{kind, l.src[start:l.pos], start, l.pos}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case isSpace(c):
+		for l.pos < len(l.src) && isSpace(l.src[l.pos]) {
+			l.pos++
+		}
+		return tok(Whitespace)
+	case strings.HasPrefix(l.src[l.pos:], "/*"):
+		end := strings.Index(l.src[l.pos+2:], "*/")
+		if end < 0 {
+			return Token{}, &SyntaxError{Offset: start, Msg: "unterminated comment"}
+		}
+		l.pos += end + 4
+		return tok(Comment)
+	case c == '"' || c == '\'':
+		if err := l.string(c); err != nil {
+			return Token{}, err
+		}
+		return tok(String)
+	case l.startsNumber():
+		l.number()
+		return tok(Number)
+	case l.startsIdent(0):
+		l.name()
+		if l.at(0) != '(' {
+			return tok(Ident)
+		}
+		l.pos++
+		if strings.EqualFold(l.src[start:l.pos], "url(") {
+			return l.url(start)
+		}
+		return tok(Function)
+	case c == '@' && l.startsIdent(1):
+		l.pos++
+		l.name()
+		return tok(AtKeyword)
+	case c == '$' && l.startsIdent(1):
+		l.pos++
+		l.name()
+		return tok(Variable)
+	case c == '#' && (isName(l.at(1)) || l.validEscape(1)):
+		l.pos++
+		l.name()
+		return tok(Hash)
+	}
+	if kind, ok := punctuators[c]; ok {
+		l.pos++
+		return tok(kind)
+	}
+	if c == '\\' {
+		// Not followed by a character it could escape
+		return Token{}, &SyntaxError{Offset: start, Msg: "invalid escape"}
+	}
+	_, w := utf8.DecodeRuneInString(l.src[l.pos:])
+	l.pos += w
+	return tok(Delim)
+}
+
+// name consumes name characters and escapes.
+func (l *lexer) name() {
+	for l.pos < len(l.src) {
+		switch {
+		case isName(l.src[l.pos]):
+			l.pos++
+		case l.validEscape(0):
+			l.escape()
+		default:
+			return
+		}
+	}
+}
+
+// escape consumes a backslash and the character or the hexadecimal code
+// point it escapes.
+func (l *lexer) escape() {
+	l.pos++
+	if !isHex(l.at(0)) {
+		_, w := utf8.DecodeRuneInString(l.src[l.pos:])
+		l.pos += w
+		return
+	}
+	for i := 0; i < 6 && isHex(l.at(0)); i++ {
+		l.pos++
+	}
+	if isSpace(l.at(0)) {
+		l.pos++
+	}
+}
+
+func (l *lexer) number() {
+	if c := l.at(0); c == '+' || c == '-' {
+		l.pos++
+	}
+	for isDigit(l.at(0)) {
+		l.pos++
+	}
+	if l.at(0) == '.' && isDigit(l.at(1)) {
+		l.pos++
+		for isDigit(l.at(0)) {
+			l.pos++
+		}
+	}
+	if c := l.at(0); c == 'e' || c == 'E' {
+		sign := 0
+		if s := l.at(1); s == '+' || s == '-' {
+			sign = 1
+		}
+		if isDigit(l.at(1 + sign)) {
+			l.pos += 1 + sign
+			for isDigit(l.at(0)) {
+				l.pos++
+			}
+		}
+	}
+	switch {
+	case l.at(0) == '%':
+		l.pos++
+	case l.startsIdent(0):
+		l.name()
+	}
+}
+
+// string consumes a string quoted by q. An escaped new line continues the
+// string, an unescaped one is an error.
+func (l *lexer) string(q byte) error {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == q:
+			l.pos++
+			return nil
+		case isNewline(c):
+			return &SyntaxError{Offset: start, Msg: "unterminated string"}
+		case c == '\\':
+			if l.pos+1 < len(l.src) && l.src[l.pos+1] == '\r' && l.at(2) == '\n' {
+				l.pos += 3
+			} else if l.pos+1 < len(l.src) && isNewline(l.src[l.pos+1]) {
+				l.pos += 2
+			} else if l.pos+1 < len(l.src) {
+				l.escape()
+			} else {
+				l.pos++
+			}
+		default:
+			l.pos++
+		}
+	}
+	return &SyntaxError{Offset: start, Msg: "unterminated string"}
+}
+
+// url consumes an unquoted url, or returns url( as a function if the url is
+// quoted.
+func (l *lexer) url(start int) (Token, error) {
+	for isSpace(l.at(0)) {
+		l.pos++
+	}
+	if c := l.at(0); c == '"' || c == '\'' {
+		l.pos = start + 4
+		return Token{Function, l.src[start:l.pos], start, l.pos}, nil
+	}
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ')':
+			l.pos++
+			return Token{URL, l.src[start:l.pos], start, l.pos}, nil
+		case isSpace(c):
+			for isSpace(l.at(0)) {
+				l.pos++
+			}
+			if l.at(0) != ')' && l.pos < len(l.src) {
+				return Token{}, &SyntaxError{Offset: l.pos, Msg: "bad url, white space inside"}
+			}
+		case c == '"' || c == '\'' || c == '(' || c < 0x20 || c == 0x7f:
+			return Token{}, &SyntaxError{Offset: l.pos, Msg: "bad url, unexpected " + string(c)}
+		case c == '\\':
+			if !l.validEscape(0) {
+				return Token{}, &SyntaxError{Offset: l.pos, Msg: "bad url, invalid escape"}
+			}
+			l.escape()
+		default:
+			l.pos++
+		}
+	}
+	return Token{}, &SyntaxError{Offset: start, Msg: "unterminated url"}
+}
@@ -0,0 +1,184 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/woobleio/wooblizer/engine/css"
+	"github.com/woobleio/wooblizer/engine/doc"
+	"github.com/woobleio/wooblizer/engine/ecma"
+)
+
+// Severity is the importance of a diagnostic.
+type Severity int
+
+// Diagnostic severities
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return "error"
+}
+
+// Diagnostic codes
+const (
+	CodeSyntax        = "syntax"
+	CodeNoClass       = "no-class"
+	CodeNoConstructor = "no-constructor"
+	CodeNoDocInit     = "no-doc-init"
+	CodeUniqueName    = "unique-name"
+	CodeParams        = "params"
+	CodeStripped      = "stripped"
+	CodeCSS           = "css"
+	CodePlaceholder   = "placeholder"
+	CodeIO            = "io"
+	CodeHelper        = "helper"
+	CodeUnsupported   = "unsupported"
+)
+
+// Position is a location in a source. Line and Column are 1-based, Column
+// counts bytes.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// Range is a portion of a source, End is exclusive.
+type Range struct {
+	Start Position
+	End   Position
+}
+
+// Fix is a suggested edit of the source which resolves a diagnostic.
+type Fix struct {
+	Message string
+	Range   Range
+	NewText string
+}
+
+// Diagnostic is a positioned problem found in a creation source. It wraps
+// the engine error it stands for, so errors.Is(d, ErrNoDocInit) holds.
+type Diagnostic struct {
+	Severity Severity
+	Code     string
+	Message  string
+	Range    Range // zero if the position is unknown
+	Fix      *Fix  // nil if no fix is suggested
+
+	// Name is the name of the creation
+	Name string
+
+	err error
+}
+
+// NewDiagnostic creates an error diagnostic wrapping err, its message is the
+// message of err.
+func NewDiagnostic(code string, err error) *Diagnostic {
+	return &Diagnostic{
+		Severity: SeverityError,
+		Code:     code,
+		Message:  err.Error(),
+		err:      err,
+	}
+}
+
+// Error formats the diagnostic the way compilers do,
+// name:line:column: severity: message [code]
+// The line and the column are left out when the position is unknown, such
+// as for the markup removed by a policy.
+func (d *Diagnostic) Error() string {
+	var pos []string
+	if d.Name != "" {
+		pos = append(pos, d.Name)
+	}
+	if d.Range.Start.Line > 0 {
+		pos = append(pos, fmt.Sprintf("%d:%d", d.Range.Start.Line, d.Range.Start.Column))
+	}
+	msg := fmt.Sprintf("%s: %s [%s]", d.Severity, d.Message, d.Code)
+	if len(pos) == 0 {
+		return msg
+	}
+	return strings.Join(pos, ":") + ": " + msg
+}
+
+// Unwrap returns the wrapped engine error.
+func (d *Diagnostic) Unwrap() error { return d.err }
+
+// HasErrors reports whether one of the diagnostics is an error.
+func HasErrors(diags []*Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// at places the diagnostic on a node of a parsed source.
+func (d *Diagnostic) at(prog *ecma.Program, n ecma.Node) *Diagnostic {
+	d.Range = nodeRange(prog, n)
+	return d
+}
+
+func position(prog *ecma.Program, offset int) Position {
+	line, col := prog.Position(offset)
+	return Position{offset, line, col}
+}
+
+func nodeRange(prog *ecma.Program, n ecma.Node) Range {
+	return Range{position(prog, n.Pos()), position(prog, n.End())}
+}
+
+// strippedDiagnostics converts the markup removed by a policy into warnings,
+// the parser of the markup does not locate the nodes so they have no range.
+func strippedDiagnostics(name string, stripped []doc.Stripped) []*Diagnostic {
+	diags := make([]*Diagnostic, len(stripped))
+	for i, s := range stripped {
+		d := NewDiagnostic(CodeStripped, ErrStripped)
+		d.Severity = SeverityWarning
+		d.Message = s.String()
+		d.Name = name
+		diags[i] = d
+	}
+	return diags
+}
+
+// syntaxDiagnostic converts a parse error, the range covers the character
+// where the parser stopped. An unsupported ES2015 feature has its own code.
+func syntaxDiagnostic(err *ecma.SyntaxError) *Diagnostic {
+	d := NewDiagnostic(CodeSyntax, err)
+	d.Message = err.Msg
+	if err.Feature != "" {
+		d.Code = CodeUnsupported
+		d.Message += ", compile the creation to ES5 with Babel"
+	}
+	start := Position{err.Offset, err.Line, err.Column}
+	end := start
+	end.Offset++
+	end.Column++
+	d.Range = Range{start, end}
+	return d
+}
+
+// cssDiagnostic converts a style sheet syntax error, the range covers the
+// character where the parser stopped.
+func cssDiagnostic(err *css.SyntaxError) *Diagnostic {
+	d := NewDiagnostic(CodeCSS, err)
+	d.Message = err.Msg
+	start := Position{err.Offset, err.Line, err.Column}
+	end := start
+	end.Offset++
+	end.Column++
+	d.Range = Range{start, end}
+	return d
+}
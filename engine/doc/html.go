@@ -1,8 +1,6 @@
 package doc
 
 import (
-	"regexp"
-
 	"strings"
 
 	h "golang.org/x/net/html"
@@ -12,24 +10,71 @@ import (
 type HTML struct {
 	doc     *h.Node
 	curNode *h.Node
+
+	policy   *Policy
+	stripped []Stripped
+
+	// Nodes skipped by ReadAndExecute, the children of excluded elements are
+	// read
+	exclTags  map[string]bool
+	exclTypes map[h.NodeType]bool
+	keepSpace bool
+
+	// lineStart is the state of the whitespace model
+	lineStart bool
 }
 
-var exclNodes []interface{}
+// Option configures an HTML parser.
+type Option func(*HTML)
+
+// WithPolicy sanitizes the markup with a policy, nil keeps all the markup.
+func WithPolicy(p *Policy) Option {
+	return func(html *HTML) { html.policy = p }
+}
+
+// KeepComments reads the comment nodes, they are excluded by default.
+func KeepComments() Option {
+	return func(html *HTML) { delete(html.exclTypes, h.CommentNode) }
+}
+
+// KeepWhitespace reads the texts verbatim. By default the white spaces are
+// collapsed, and dropped where they do not render.
+func KeepWhitespace() Option {
+	return func(html *HTML) { html.keepSpace = true }
+}
+
+// ExcludeTags excludes elements by tag name, their children are still read.
+func ExcludeTags(tags ...string) Option {
+	return func(html *HTML) {
+		for _, tag := range tags {
+			html.exclTags[tag] = true
+		}
+	}
+}
 
 // NewHTML creates a new HTML parser
-func NewHTML(doc string) (*HTML, error) {
+func NewHTML(doc string, opts ...Option) (*HTML, error) {
 	r := strings.NewReader(doc)
 	node, err := h.Parse(r)
 	if err != nil {
 		return nil, err
 	}
 
-	addExcludedNodes("body", "html", "head", h.DoctypeNode, h.ErrorNode, h.DocumentNode, h.CommentNode)
-
-	return &HTML{
-		node,
-		node,
-	}, nil
+	html := &HTML{
+		doc:       node,
+		curNode:   node,
+		lineStart: true,
+		exclTags: map[string]bool{
+			"body": true, "html": true, "head": true,
+		},
+		exclTypes: map[h.NodeType]bool{
+			h.DoctypeNode: true, h.ErrorNode: true, h.DocumentNode: true, h.CommentNode: true,
+		},
+	}
+	for _, opt := range opts {
+		opt(html)
+	}
+	return html, nil
 }
 
 // ReadAndExecute is a recursive function that takes a callback function as parameters.
@@ -38,40 +83,61 @@ func NewHTML(doc string) (*HTML, error) {
 func (html *HTML) ReadAndExecute(fn func(*h.Node, int) int, pIndex int) {
 	n := html.curNode
 
-	// Fixes html string format, avoid " " text nodes, for insecable space use &nbsp;
-	isInvalid, _ := regexp.MatchString("^\\s+$", n.Data)
-	if isInvalid {
-		return
+	if n.Type == h.TextNode && !html.keepSpace {
+		if n.Data = html.text(n); n.Data == "" {
+			return
+		}
 	}
 
-	if !isExcludedNode(n) {
+	if !html.isExcluded(n) {
+		if n.Type == h.ElementNode && html.policy != nil && !html.policy.filter(n, html.strip) {
+			return
+		}
 		pIndex = fn(n, pIndex)
 	}
-	for c := html.curNode.FirstChild; c != nil; c = c.NextSibling {
+	if n.Type == h.ElementNode {
+		html.element(n, true)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
 		html.curNode = c
 		html.ReadAndExecute(fn, pIndex)
 	}
-}
-
-// addExcludedNodes add nodes to be excluded from the parser
-func addExcludedNodes(nodes ...interface{}) {
-	for _, n := range nodes {
-		exclNodes = append(exclNodes, n)
+	if n.Type == h.ElementNode {
+		html.element(n, false)
 	}
 }
 
-func isExcludedNode(node *h.Node) bool {
-	for _, n := range exclNodes {
-		switch n.(type) {
-		case string:
-			if node.Data == n {
-				return true
-			}
-		case h.NodeType:
-			if node.Type == n {
-				return true
-			}
+// Fragment reads the markup like ReadAndExecute does, and returns a copy of
+// the nodes read. The top-level nodes are the children of the returned
+// document node.
+func (html *HTML) Fragment() *h.Node {
+	root := &h.Node{Type: h.DocumentNode}
+	nodes := []*h.Node{root}
+	html.ReadAndExecute(func(n *h.Node, pIndex int) int {
+		c := &h.Node{
+			Type:      n.Type,
+			DataAtom:  n.DataAtom,
+			Data:      n.Data,
+			Namespace: n.Namespace,
+			Attr:      append([]h.Attribute(nil), n.Attr...),
 		}
-	}
-	return false
+		nodes[pIndex].AppendChild(c)
+		nodes = append(nodes, c)
+		return len(nodes) - 1
+	}, 0)
+	return root
+}
+
+// Stripped returns the markup removed by the policy.
+func (html *HTML) Stripped() []Stripped {
+	return html.stripped
+}
+
+func (html *HTML) strip(s Stripped) {
+	html.stripped = append(html.stripped, s)
+}
+
+func (html *HTML) isExcluded(node *h.Node) bool {
+	// Tag names, a text may be "body"
+	return html.exclTypes[node.Type] || (node.Type == h.ElementNode && html.exclTags[node.Data])
 }
@@ -0,0 +1,114 @@
+package doc_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	h "golang.org/x/net/html"
+
+	"github.com/woobleio/wooblizer/engine/doc"
+)
+
+// read returns the nodes read by ReadAndExecute, as tag names, #text and
+// #comment.
+func read(t *testing.T, src string, opts ...doc.Option) string {
+	html, err := doc.NewHTML(src, opts...)
+	if err != nil {
+		// Called from goroutines, so no t.Fatal
+		t.Error(err)
+		return ""
+	}
+	var nodes []string
+	html.ReadAndExecute(func(n *h.Node, i int) int {
+		switch n.Type {
+		case h.TextNode:
+			nodes = append(nodes, "#text")
+		case h.CommentNode:
+			nodes = append(nodes, "#comment")
+		default:
+			nodes = append(nodes, n.Data)
+		}
+		return i
+	}, 0)
+	return strings.Join(nodes, " ")
+}
+
+func TestNewHTMLOptions(t *testing.T) {
+	src := "<div><!-- note --> <my-tag><p>body</p></my-tag></div>"
+
+	tests := []struct {
+		opts     []doc.Option
+		expected string
+	}{
+		{nil, "div my-tag p #text"},
+		{[]doc.Option{doc.KeepComments()}, "div #comment my-tag p #text"},
+		{[]doc.Option{doc.KeepWhitespace()}, "div #text my-tag p #text"},
+		{[]doc.Option{doc.ExcludeTags("my-tag")}, "div p #text"},
+	}
+	for i, test := range tests {
+		if nodes := read(t, src, test.opts...); nodes != test.expected {
+			t.Errorf("Options %d : Expected %q, got %q", i, test.expected, nodes)
+		}
+	}
+
+	// Options of a parser do not change the others
+	if nodes := read(t, src); nodes != tests[0].expected {
+		t.Errorf("Default : Expected %q, got %q", tests[0].expected, nodes)
+	}
+}
+
+func TestNewHTMLConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			opts := []doc.Option{doc.KeepComments()}
+			expected := "div #comment p #text"
+			if i%2 == 0 {
+				opts = []doc.Option{doc.ExcludeTags("p")}
+				expected = "div #text"
+			}
+			for j := 0; j < 50; j++ {
+				if nodes := read(t, "<div><!--c--><p>x</p></div>", opts...); nodes != expected {
+					t.Errorf("Goroutine %d : Expected %q, got %q", i, expected, nodes)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestWhitespace(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected []string
+	}{
+		{"<b>a</b> <i>b</i>", []string{"a", " ", "b"}},
+		{"<p>\n  some   text\n  <b> bold </b>\n</p>\n<p>x</p>", []string{"some text ", "bold ", "x"}},
+		{"<pre>\n  keep\n\tit  </pre>", []string{"  keep\n\tit  "}},
+		{"<textarea>  a\n b </textarea>", []string{"  a\n b "}},
+		{"<ul>\n  <li>a</li>\n  <li>b</li>\n</ul>", []string{"a", "b"}},
+		{"<table> <tr> <td> a </td> </tr> </table>", []string{"a "}},
+		{"a<br> b", []string{"a", "b"}},
+		{"<img> <img>", []string{" "}},
+	}
+	for _, test := range tests {
+		html, err := doc.NewHTML(test.src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		texts := make([]string, 0)
+		html.ReadAndExecute(func(n *h.Node, i int) int {
+			if n.Type == h.TextNode {
+				texts = append(texts, n.Data)
+			}
+			return i
+		}, 0)
+		if strings.Join(texts, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("%q : Expected texts %q, got %q", test.src, test.expected, texts)
+		}
+	}
+}
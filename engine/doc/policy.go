@@ -0,0 +1,152 @@
+package doc
+
+import (
+	"strings"
+
+	h "golang.org/x/net/html"
+)
+
+// Policy is an allow-list of the markup kept by ReadAndExecute. The elements
+// which are not allowed are removed with their content, the attributes which
+// are not allowed are removed from their element.
+type Policy struct {
+	Elements   map[string]bool
+	Attributes map[string]bool
+
+	// AttributePrefixes allows the attributes starting with them, such as
+	// data- or aria-
+	AttributePrefixes []string
+
+	// URLAttributes are the attributes holding a URL, their value must be
+	// relative or use one of URLSchemes
+	URLAttributes map[string]bool
+	URLSchemes    map[string]bool
+}
+
+// Preset is the name of a predefined policy.
+type Preset string
+
+// Policy presets
+const (
+	// None keeps all the markup
+	None Preset = "none"
+	// Strict keeps the text formatting, lists, tables, links and images
+	Strict Preset = "strict"
+	// Relaxed also keeps forms, media and the style and data- attributes
+	Relaxed Preset = "relaxed"
+)
+
+// NewPolicy returns a new policy from a preset, it may be changed by the
+// caller. It returns nil for None and unknown presets.
+func NewPolicy(preset Preset) *Policy {
+	if preset != Strict && preset != Relaxed {
+		return nil
+	}
+
+	p := &Policy{
+		Elements: set("a", "abbr", "article", "aside", "b", "blockquote", "br", "caption", "cite", "code",
+			"dd", "del", "dfn", "div", "dl", "dt", "em", "figcaption", "figure", "footer", "h1", "h2", "h3",
+			"h4", "h5", "h6", "header", "hr", "i", "img", "ins", "kbd", "li", "main", "mark", "nav", "ol",
+			"p", "pre", "q", "s", "samp", "section", "small", "span", "strong", "sub", "sup", "table",
+			"tbody", "td", "tfoot", "th", "thead", "tr", "u", "ul", "var"),
+		Attributes: set("alt", "cite", "class", "colspan", "dir", "height", "href", "id", "lang",
+			"rowspan", "src", "title", "width"),
+		AttributePrefixes: []string{"aria-"},
+		URLAttributes:     set("action", "background", "cite", "formaction", "href", "poster", "src", "xlink:href"),
+		URLSchemes:        set("http", "https", "mailto"),
+	}
+
+	if preset == Relaxed {
+		add(p.Elements, "audio", "button", "canvas", "details", "fieldset", "form", "input",
+			"label", "legend", "optgroup", "option", "picture", "select", "source", "summary",
+			"textarea", "time", "track", "video", "wbr")
+		add(p.Attributes, "action", "autoplay", "checked", "cols", "controls", "datetime",
+			"disabled", "for", "hidden", "label", "loop", "max", "maxlength", "method", "min", "muted",
+			"name", "open", "pattern", "placeholder", "poster", "readonly", "rel", "required", "role",
+			"rows", "selected", "step", "style", "tabindex", "target", "type", "value")
+		p.AttributePrefixes = append(p.AttributePrefixes, "data-")
+		add(p.URLSchemes, "ftp", "tel")
+	}
+	return p
+}
+
+func set(items ...string) map[string]bool {
+	s := make(map[string]bool, len(items))
+	add(s, items...)
+	return s
+}
+
+func add(s map[string]bool, items ...string) {
+	for _, item := range items {
+		s[item] = true
+	}
+}
+
+// Stripped is an element or an attribute removed by a policy.
+type Stripped struct {
+	Element   string
+	Attribute string // empty when the element is removed
+	Value     string // the value of the attribute
+}
+
+func (s Stripped) String() string {
+	if s.Attribute == "" {
+		return "element <" + s.Element + "> removed"
+	}
+	return "attribute " + s.Attribute + "=\"" + s.Value + "\" removed from <" + s.Element + ">"
+}
+
+// filter removes the attributes of an element which are not allowed, and
+// reports whether the element itself is allowed.
+func (p *Policy) filter(n *h.Node, strip func(Stripped)) bool {
+	if !p.Elements[n.Data] {
+		strip(Stripped{Element: n.Data})
+		return false
+	}
+
+	attrs := n.Attr[:0]
+	for _, attr := range n.Attr {
+		key := attr.Key
+		if attr.Namespace != "" {
+			key = attr.Namespace + ":" + key
+		}
+		if p.allowAttr(key, attr.Val) {
+			attrs = append(attrs, attr)
+			continue
+		}
+		strip(Stripped{n.Data, key, attr.Val})
+	}
+	n.Attr = attrs
+	return true
+}
+
+func (p *Policy) allowAttr(key string, val string) bool {
+	allowed := p.Attributes[key]
+	for _, prefix := range p.AttributePrefixes {
+		allowed = allowed || strings.HasPrefix(key, prefix)
+	}
+	if !allowed {
+		return false
+	}
+	if p.URLAttributes[key] {
+		scheme := urlScheme(val)
+		return scheme == "" || p.URLSchemes[scheme]
+	}
+	return true
+}
+
+// urlScheme returns the lower cased scheme of a URL, empty if it is relative.
+// Browsers ignore the white spaces and control characters, so does it.
+func urlScheme(url string) string {
+	url = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, url)
+	i := strings.IndexAny(url, ":/?#")
+	if i <= 0 || url[i] != ':' {
+		return ""
+	}
+	return strings.ToLower(url[:i])
+}
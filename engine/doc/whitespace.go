@@ -0,0 +1,132 @@
+package doc
+
+import (
+	"strings"
+
+	h "golang.org/x/net/html"
+)
+
+// The whitespace model follows the default rendering of HTML: white spaces
+// are kept verbatim in preformatted elements, elsewhere a run of white spaces
+// renders as a single space, which does not render at the start of a line nor
+// between blocks.
+
+// preformatted are the elements whose text is kept verbatim.
+var preformatted = map[string]bool{
+	"pre": true, "textarea": true, "listing": true, "plaintext": true, "xmp": true,
+	"script": true, "style": true,
+}
+
+// blocks are the elements starting a new line.
+var blocks = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true,
+	"caption": true, "dd": true, "details": true, "dialog": true, "div": true, "dl": true,
+	"dt": true, "fieldset": true, "figcaption": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hgroup": true, "hr": true, "html": true, "legend": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true, "section": true,
+	"summary": true, "table": true, "tbody": true, "td": true, "tfoot": true, "th": true,
+	"thead": true, "tr": true, "ul": true,
+}
+
+// textless are the elements whose text children do not render.
+var textless = map[string]bool{
+	"colgroup": true, "dl": true, "head": true, "html": true, "ol": true, "optgroup": true,
+	"select": true, "table": true, "tbody": true, "tfoot": true, "thead": true, "tr": true,
+	"ul": true,
+}
+
+// replaced are the inline elements rendering a content of their own.
+var replaced = map[string]bool{
+	"audio": true, "button": true, "canvas": true, "embed": true, "iframe": true, "img": true,
+	"input": true, "math": true, "meter": true, "object": true, "progress": true,
+	"select": true, "svg": true, "textarea": true, "video": true,
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\f' || r == '\r'
+}
+
+// collapse replaces the runs of white spaces with a single space.
+func collapse(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if isSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+func isPreformatted(n *h.Node) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == h.ElementNode && preformatted[p.Data] {
+			return true
+		}
+	}
+	return false
+}
+
+// nextRendered returns the following sibling, comments skipped.
+func nextRendered(n *h.Node) *h.Node {
+	next := n.NextSibling
+	for next != nil && next.Type == h.CommentNode {
+		next = next.NextSibling
+	}
+	return next
+}
+
+// text returns the rendered text of a text node, empty if it does not
+// render. lineStart is set when the last rendered text ends with a white
+// space, or at the start of a line.
+func (html *HTML) text(n *h.Node) string {
+	if isPreformatted(n) {
+		html.lineStart = false
+		return n.Data
+	}
+
+	parent := n.Parent
+	if parent != nil && parent.Type == h.ElementNode && textless[parent.Data] {
+		return ""
+	}
+
+	s := collapse(n.Data)
+	if html.lineStart {
+		s = strings.TrimPrefix(s, " ")
+	}
+	if s == " " {
+		// A space at the end of a block, or before a block
+		next := nextRendered(n)
+		if next == nil && parent != nil && parent.Type == h.ElementNode && blocks[parent.Data] {
+			return ""
+		}
+		if next != nil && next.Type == h.ElementNode && blocks[next.Data] {
+			return ""
+		}
+	}
+	if s != "" {
+		html.lineStart = strings.HasSuffix(s, " ")
+	}
+	return s
+}
+
+// element updates the line state around an element, enter is set before its
+// children are read.
+func (html *HTML) element(n *h.Node, enter bool) {
+	switch {
+	case blocks[n.Data] || n.Data == "br":
+		html.lineStart = true
+	case replaced[n.Data] && !enter:
+		html.lineStart = false
+	}
+}
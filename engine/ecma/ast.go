@@ -0,0 +1,433 @@
+// Package ecma parses ECMAScript 5 sources, ES2015 classes and the export
+// declarations of modules, into an abstract syntax tree.
+package ecma
+
+// Node is implemented by every node of the tree, Pos and End are the byte
+// offsets of the node in the parsed source.
+type Node interface {
+	Pos() int
+	End() int
+}
+
+// Stmt is a statement node.
+type Stmt interface {
+	Node
+	stmtNode()
+}
+
+// Expr is an expression node.
+type Expr interface {
+	Node
+	exprNode()
+}
+
+// Span is the source range of a node.
+type Span struct {
+	Start int
+	Stop  int
+}
+
+// Pos returns the offset of the first byte of the node.
+func (s Span) Pos() int { return s.Start }
+
+// End returns the offset following the last byte of the node.
+func (s Span) End() int { return s.Stop }
+
+// Program is the root of a parsed source.
+type Program struct {
+	Span
+	Body []Stmt
+
+	src   string
+	lines []int
+}
+
+// Position converts a byte offset of the program source into a 1-based line
+// and column.
+func (p *Program) Position(offset int) (line int, column int) {
+	i, j := 0, len(p.lines)
+	for i < j {
+		h := (i + j) / 2
+		if p.lines[h] <= offset {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i, offset - p.lines[i-1] + 1
+}
+
+// Source returns the source text of a node.
+func (p *Program) Source(n Node) string { return p.src[n.Pos():n.End()] }
+
+// Statements
+
+type (
+	// VarDecl is a var statement
+	VarDecl struct {
+		Span
+		List []*VarBinding
+	}
+
+	// VarBinding is a single declarator of a var statement
+	VarBinding struct {
+		Span
+		Name *Ident
+		Init Expr // nil when not initialized
+	}
+
+	// FuncDecl is a function declaration
+	FuncDecl struct {
+		Span
+		Func *Func
+	}
+
+	// ClassDecl is a class declaration
+	ClassDecl struct {
+		Span
+		Class *Class
+	}
+
+	// ExprStmt is an expression used as a statement
+	ExprStmt struct {
+		Span
+		X Expr
+	}
+
+	// BlockStmt is a list of statements between braces
+	BlockStmt struct {
+		Span
+		List []Stmt
+	}
+
+	// EmptyStmt is a lone semicolon
+	EmptyStmt struct {
+		Span
+	}
+
+	// IfStmt is an if statement
+	IfStmt struct {
+		Span
+		Cond Expr
+		Then Stmt
+		Else Stmt // nil if there is no else branch
+	}
+
+	// ForStmt is a C-like for loop, Init is either a *VarDecl or an Expr
+	ForStmt struct {
+		Span
+		Init   Node
+		Cond   Expr
+		Update Expr
+		Body   Stmt
+	}
+
+	// ForInStmt is a for-in loop, Left is either a *VarDecl or an Expr
+	ForInStmt struct {
+		Span
+		Left  Node
+		Right Expr
+		Body  Stmt
+	}
+
+	// WhileStmt is a while loop
+	WhileStmt struct {
+		Span
+		Cond Expr
+		Body Stmt
+	}
+
+	// DoWhileStmt is a do-while loop
+	DoWhileStmt struct {
+		Span
+		Body Stmt
+		Cond Expr
+	}
+
+	// ReturnStmt is a return statement
+	ReturnStmt struct {
+		Span
+		Result Expr
+	}
+
+	// BranchStmt is a break or continue statement
+	BranchStmt struct {
+		Span
+		Tok   string
+		Label *Ident
+	}
+
+	// ThrowStmt is a throw statement
+	ThrowStmt struct {
+		Span
+		X Expr
+	}
+
+	// TryStmt is a try statement
+	TryStmt struct {
+		Span
+		Block     *BlockStmt
+		Param     *Ident // nil if there is no catch clause
+		Handler   *BlockStmt
+		Finalizer *BlockStmt
+	}
+
+	// SwitchStmt is a switch statement
+	SwitchStmt struct {
+		Span
+		Tag   Expr
+		Cases []*CaseClause
+	}
+
+	// CaseClause is a case of a switch, Test is nil for default
+	CaseClause struct {
+		Span
+		Test Expr
+		Body []Stmt
+	}
+
+	// LabeledStmt is a labeled statement
+	LabeledStmt struct {
+		Span
+		Label *Ident
+		Body  Stmt
+	}
+
+	// WithStmt is a with statement
+	WithStmt struct {
+		Span
+		Object Expr
+		Body   Stmt
+	}
+
+	// DebuggerStmt is a debugger statement
+	DebuggerStmt struct {
+		Span
+	}
+
+	// ExportDecl is the export of a module: a declaration, a default
+	// expression, or a list of names
+	ExportDecl struct {
+		Span
+		Decl    Stmt // *VarDecl, *FuncDecl or *ClassDecl
+		Default Expr
+		Specs   []*ExportSpec
+	}
+
+	// ExportSpec is a name of an export list
+	ExportSpec struct {
+		Span
+		Local    *Ident
+		Exported *Ident
+	}
+)
+
+func (*VarDecl) stmtNode()      {}
+func (*FuncDecl) stmtNode()     {}
+func (*ClassDecl) stmtNode()    {}
+func (*ExprStmt) stmtNode()     {}
+func (*BlockStmt) stmtNode()    {}
+func (*EmptyStmt) stmtNode()    {}
+func (*IfStmt) stmtNode()       {}
+func (*ForStmt) stmtNode()      {}
+func (*ForInStmt) stmtNode()    {}
+func (*WhileStmt) stmtNode()    {}
+func (*DoWhileStmt) stmtNode()  {}
+func (*ReturnStmt) stmtNode()   {}
+func (*BranchStmt) stmtNode()   {}
+func (*ThrowStmt) stmtNode()    {}
+func (*TryStmt) stmtNode()      {}
+func (*SwitchStmt) stmtNode()   {}
+func (*LabeledStmt) stmtNode()  {}
+func (*WithStmt) stmtNode()     {}
+func (*DebuggerStmt) stmtNode() {}
+func (*ExportDecl) stmtNode()   {}
+
+// Expressions
+
+type (
+	// Ident is an identifier
+	Ident struct {
+		Span
+		Name string
+	}
+
+	// Literal is a number, string, regular expression, boolean or null
+	// literal
+	Literal struct {
+		Span
+		Kind  TokenKind // Number, String, RegExp or Keyword
+		Raw   string
+		Value string // decoded string value
+	}
+
+	// ThisExpr is the this keyword
+	ThisExpr struct {
+		Span
+	}
+
+	// ArrayLit is an array literal, holes are nil elements
+	ArrayLit struct {
+		Span
+		List []Expr
+	}
+
+	// ObjectLit is an object literal
+	ObjectLit struct {
+		Span
+		Props []*Property
+	}
+
+	// Property is a property of an object literal, Kind is "init", "get" or
+	// "set"
+	Property struct {
+		Span
+		Kind  string
+		Key   *Literal // a name key is stored as a string literal
+		Value Expr
+	}
+
+	// Func is a function expression, and the function of a declaration
+	Func struct {
+		Span
+		Name   *Ident // nil for anonymous functions
+		Params []*Ident
+		Body   *BlockStmt
+
+		// Lparen and Rparen are the offsets of the parameter list parentheses
+		Lparen int
+		Rparen int
+	}
+
+	// Class is a class expression, and the class of a declaration
+	Class struct {
+		Span
+		Name    *Ident // nil for anonymous classes
+		Super   Expr   // nil if the class does not extend another
+		Methods []*Method
+	}
+
+	// Method is a method definition of a class, Kind is "constructor",
+	// "method", "get" or "set"
+	Method struct {
+		Span
+		Static bool
+		Kind   string
+		Key    *Literal // a name key is stored as a string literal
+		Value  *Func
+	}
+
+	// SuperExpr is the super keyword, callee or object of a member access
+	SuperExpr struct {
+		Span
+	}
+
+	// ImportMeta is import.meta, the metadata of a module
+	ImportMeta struct {
+		Span
+	}
+
+	// UnaryExpr is a prefix operator expression
+	UnaryExpr struct {
+		Span
+		Op string
+		X  Expr
+	}
+
+	// UpdateExpr is an increment or decrement
+	UpdateExpr struct {
+		Span
+		Op     string
+		Prefix bool
+		X      Expr
+	}
+
+	// BinaryExpr is a binary or logical operator expression
+	BinaryExpr struct {
+		Span
+		Op string
+		X  Expr
+		Y  Expr
+	}
+
+	// AssignExpr is an assignment
+	AssignExpr struct {
+		Span
+		Op    string
+		Left  Expr
+		Right Expr
+	}
+
+	// CondExpr is a ternary conditional
+	CondExpr struct {
+		Span
+		Test Expr
+		Then Expr
+		Else Expr
+	}
+
+	// CallExpr is a function call
+	CallExpr struct {
+		Span
+		Callee Expr
+		Args   []Expr
+	}
+
+	// NewExpr is a new expression
+	NewExpr struct {
+		Span
+		Callee Expr
+		Args   []Expr
+	}
+
+	// MemberExpr is a property access, Prop is a *Ident for dot accesses
+	MemberExpr struct {
+		Span
+		Object   Expr
+		Prop     Expr
+		Computed bool
+	}
+
+	// SeqExpr is a comma separated list of expressions
+	SeqExpr struct {
+		Span
+		List []Expr
+	}
+
+	// ParenExpr is an expression between parentheses
+	ParenExpr struct {
+		Span
+		X Expr
+	}
+)
+
+func (*Ident) exprNode()      {}
+func (*Literal) exprNode()    {}
+func (*ThisExpr) exprNode()   {}
+func (*ArrayLit) exprNode()   {}
+func (*ObjectLit) exprNode()  {}
+func (*Func) exprNode()       {}
+func (*Class) exprNode()      {}
+func (*SuperExpr) exprNode()  {}
+func (*ImportMeta) exprNode() {}
+func (*UnaryExpr) exprNode()  {}
+func (*UpdateExpr) exprNode() {}
+func (*BinaryExpr) exprNode() {}
+func (*AssignExpr) exprNode() {}
+func (*CondExpr) exprNode()   {}
+func (*CallExpr) exprNode()   {}
+func (*NewExpr) exprNode()    {}
+func (*MemberExpr) exprNode() {}
+func (*SeqExpr) exprNode()    {}
+func (*ParenExpr) exprNode()  {}
+
+// Unparen strips the parentheses around an expression.
+func Unparen(x Expr) Expr {
+	for {
+		p, ok := x.(*ParenExpr)
+		if !ok {
+			return x
+		}
+		x = p.X
+	}
+}
@@ -0,0 +1,23 @@
+package ecma
+
+import "fmt"
+
+// SyntaxError is returned when a source cannot be parsed.
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the source
+	Offset int
+
+	// Line and Column are 1-based, set by Parse
+	Line   int
+	Column int
+
+	Msg string
+
+	// Feature is the unsupported ES2015 feature found, such as "arrow
+	// functions", or "" if the source is invalid
+	Feature string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Msg)
+}
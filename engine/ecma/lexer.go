@@ -0,0 +1,333 @@
+package ecma
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// lexer splits an ECMAScript source into tokens. It is driven by the parser
+// which tells it when a slash starts a regular expression.
+type lexer struct {
+	src string
+	pos int
+}
+
+func isLineTerminator(r rune) bool {
+	return r == '\n' || r == '\r' || r == '\u2028' || r == '\u2029'
+}
+
+func isIdentStart(r rune) bool {
+	return r == '$' || r == '_' || r == '\\' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) ||
+		unicode.Is(unicode.Mc, r) || unicode.Is(unicode.Pc, r) || r == '\u200c' || r == '\u200d'
+}
+
+func isDecimal(c byte) bool { return c >= '0' && c <= '9' }
+
+func isHex(c byte) bool {
+	return isDecimal(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func (l *lexer) peekRune(off int) (rune, int) {
+	if l.pos+off >= len(l.src) {
+		return -1, 0
+	}
+	return utf8.DecodeRuneInString(l.src[l.pos+off:])
+}
+
+// skipSpace skips white spaces and comments, it reports whether a line
+// terminator was crossed.
+func (l *lexer) skipSpace() (bool, error) {
+	nl := false
+	for l.pos < len(l.src) {
+		r, w := l.peekRune(0)
+		switch {
+		case isLineTerminator(r):
+			nl = true
+			l.pos += w
+		case r == ' ' || r == '\t' || r == '\v' || r == '\f' || r == '\u00a0' || r == '\ufeff' || unicode.Is(unicode.Zs, r):
+			l.pos += w
+		case strings.HasPrefix(l.src[l.pos:], "//"):
+			for l.pos < len(l.src) {
+				r, w := l.peekRune(0)
+				if isLineTerminator(r) {
+					break
+				}
+				l.pos += w
+			}
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end < 0 {
+				return nl, &SyntaxError{Offset: l.pos, Msg: "unterminated comment"}
+			}
+			if strings.ContainsAny(l.src[l.pos:l.pos+2+end], "\n\r\u2028\u2029") {
+				nl = true
+			}
+			l.pos += end + 4
+		default:
+			return nl, nil
+		}
+	}
+	return nl, nil
+}
+
+// next scans the next token, a slash is read as a division punctuator.
+func (l *lexer) next() (Token, error) {
+	nl, err := l.skipSpace()
+	if err != nil {
+		return Token{}, err
+	}
+	start := l.pos
+	tok := Token{Start: start, NewlineBefore: nl}
+	if l.pos >= len(l.src) {
+		tok.Kind = EOF
+		tok.End = l.pos
+		return tok, nil
+	}
+
+	r, _ := l.peekRune(0)
+	c := l.src[l.pos]
+	switch {
+	case isIdentStart(r):
+		name, err := l.scanIdentifier()
+		if err != nil {
+			return tok, err
+		}
+		tok.Kind = Identifier
+		if keywords[name] && !strings.Contains(l.src[start:l.pos], "\\") {
+			tok.Kind = Keyword
+		}
+		tok.Value = name
+	case isDecimal(c) || (c == '.' && l.pos+1 < len(l.src) && isDecimal(l.src[l.pos+1])):
+		if err := l.scanNumber(); err != nil {
+			return tok, err
+		}
+		tok.Kind = Number
+	case c == '`':
+		return tok, &SyntaxError{Offset: start, Msg: "unsupported ES2015 feature: template literals", Feature: "template literals"}
+	case c == '"' || c == '\'':
+		s, err := l.scanString(c)
+		if err != nil {
+			return tok, err
+		}
+		tok.Kind = String
+		tok.Value = s
+	default:
+		for _, p := range punctuators {
+			if strings.HasPrefix(l.src[l.pos:], p) {
+				tok.Kind = Punctuator
+				l.pos += len(p)
+				break
+			}
+		}
+		if tok.Kind != Punctuator {
+			return tok, &SyntaxError{Offset: start, Msg: "unexpected character " + strconv.QuoteRune(r)}
+		}
+	}
+
+	tok.End = l.pos
+	tok.Raw = l.src[start:l.pos]
+	if tok.Kind != String && tok.Kind != Identifier && tok.Kind != Keyword {
+		tok.Value = tok.Raw
+	}
+	return tok, nil
+}
+
+func (l *lexer) scanIdentifier() (string, error) {
+	var b strings.Builder
+	first := true
+	for l.pos < len(l.src) {
+		r, w := l.peekRune(0)
+		if (first && !isIdentStart(r)) || (!first && !isIdentPart(r)) {
+			break
+		}
+		if r == '\\' {
+			if !strings.HasPrefix(l.src[l.pos:], "\\u") {
+				return "", &SyntaxError{Offset: l.pos, Msg: "invalid escape in identifier"}
+			}
+			l.pos += 2
+			cp, err := l.scanHexDigits(4)
+			if err != nil {
+				return "", err
+			}
+			r, w = cp, 0
+		}
+		b.WriteRune(r)
+		l.pos += w
+		first = false
+	}
+	return b.String(), nil
+}
+
+func (l *lexer) scanHexDigits(n int) (rune, error) {
+	if l.pos+n > len(l.src) {
+		return 0, &SyntaxError{Offset: l.pos, Msg: "invalid hexadecimal escape"}
+	}
+	v, err := strconv.ParseUint(l.src[l.pos:l.pos+n], 16, 32)
+	if err != nil {
+		return 0, &SyntaxError{Offset: l.pos, Msg: "invalid hexadecimal escape"}
+	}
+	l.pos += n
+	return rune(v), nil
+}
+
+func (l *lexer) scanNumber() error {
+	start := l.pos
+	if strings.HasPrefix(l.src[l.pos:], "0x") || strings.HasPrefix(l.src[l.pos:], "0X") {
+		l.pos += 2
+		for l.pos < len(l.src) && isHex(l.src[l.pos]) {
+			l.pos++
+		}
+		if l.pos == start+2 {
+			return &SyntaxError{Offset: start, Msg: "invalid hexadecimal number"}
+		}
+	} else {
+		for l.pos < len(l.src) && isDecimal(l.src[l.pos]) {
+			l.pos++
+		}
+		if l.pos < len(l.src) && l.src[l.pos] == '.' {
+			l.pos++
+			for l.pos < len(l.src) && isDecimal(l.src[l.pos]) {
+				l.pos++
+			}
+		}
+		if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+			l.pos++
+			if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+				l.pos++
+			}
+			expStart := l.pos
+			for l.pos < len(l.src) && isDecimal(l.src[l.pos]) {
+				l.pos++
+			}
+			if l.pos == expStart {
+				return &SyntaxError{Offset: start, Msg: "invalid number exponent"}
+			}
+		}
+	}
+	if r, _ := l.peekRune(0); r != -1 && isIdentStart(r) {
+		return &SyntaxError{Offset: l.pos, Msg: "identifier starts immediately after number"}
+	}
+	return nil
+}
+
+func (l *lexer) scanString(quote byte) (string, error) {
+	start := l.pos
+	l.pos++
+	var b strings.Builder
+	for {
+		if l.pos >= len(l.src) {
+			return "", &SyntaxError{Offset: start, Msg: "unterminated string"}
+		}
+		r, w := l.peekRune(0)
+		switch {
+		case r == rune(quote):
+			l.pos += w
+			return b.String(), nil
+		case r == '\n' || r == '\r':
+			return "", &SyntaxError{Offset: start, Msg: "unterminated string"}
+		case r == '\\':
+			l.pos++
+			if err := l.scanEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteRune(r)
+			l.pos += w
+		}
+	}
+}
+
+// scanEscape decodes the escape sequence following a backslash.
+func (l *lexer) scanEscape(b *strings.Builder) error {
+	r, w := l.peekRune(0)
+	if r == -1 {
+		return &SyntaxError{Offset: l.pos, Msg: "unterminated escape sequence"}
+	}
+	l.pos += w
+	switch r {
+	case 'n':
+		b.WriteByte('\n')
+	case 't':
+		b.WriteByte('\t')
+	case 'r':
+		b.WriteByte('\r')
+	case 'b':
+		b.WriteByte('\b')
+	case 'f':
+		b.WriteByte('\f')
+	case 'v':
+		b.WriteByte('\v')
+	case '0':
+		if l.pos < len(l.src) && isDecimal(l.src[l.pos]) {
+			return &SyntaxError{Offset: l.pos - 1, Msg: "octal escape sequences are not allowed"}
+		}
+		b.WriteByte(0)
+	case 'x':
+		cp, err := l.scanHexDigits(2)
+		if err != nil {
+			return err
+		}
+		b.WriteRune(cp)
+	case 'u':
+		cp, err := l.scanHexDigits(4)
+		if err != nil {
+			return err
+		}
+		b.WriteRune(cp)
+	case '\r':
+		// Line continuation
+		if l.pos < len(l.src) && l.src[l.pos] == '\n' {
+			l.pos++
+		}
+	case '\n', '\u2028', '\u2029':
+		// Line continuation
+	default:
+		b.WriteRune(r)
+	}
+	return nil
+}
+
+// scanRegExp rescans from start as a regular expression literal, the parser
+// calls it when a slash stands where an expression is expected.
+func (l *lexer) scanRegExp(start int) (Token, error) {
+	l.pos = start + 1
+	inClass := false
+	for {
+		if l.pos >= len(l.src) {
+			return Token{}, &SyntaxError{Offset: start, Msg: "unterminated regular expression"}
+		}
+		r, w := l.peekRune(0)
+		if isLineTerminator(r) {
+			return Token{}, &SyntaxError{Offset: start, Msg: "unterminated regular expression"}
+		}
+		l.pos += w
+		if r == '\\' {
+			r, w = l.peekRune(0)
+			if r == -1 || isLineTerminator(r) {
+				return Token{}, &SyntaxError{Offset: start, Msg: "unterminated regular expression"}
+			}
+			l.pos += w
+		} else if r == '[' {
+			inClass = true
+		} else if r == ']' {
+			inClass = false
+		} else if r == '/' && !inClass {
+			break
+		}
+	}
+	for l.pos < len(l.src) {
+		r, w := l.peekRune(0)
+		if !isIdentPart(r) {
+			break
+		}
+		l.pos += w
+	}
+	raw := l.src[start:l.pos]
+	return Token{Kind: RegExp, Raw: raw, Value: raw, Start: start, End: l.pos}, nil
+}
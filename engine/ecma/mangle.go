@@ -0,0 +1,260 @@
+package ecma
+
+import "sort"
+
+// scope is a function scope, or the scope of a catch clause parameter.
+type scope struct {
+	parent   *scope
+	children []*scope
+
+	decls map[string]*binding
+	order []*binding
+
+	// free holds the unresolved names referenced in the scope and its
+	// children, a binding must not be renamed to one of them
+	free map[string]bool
+
+	// fixed is set when eval or with is used in the scope or its children,
+	// the bindings keep their names then
+	fixed bool
+}
+
+// binding is a declared name and all the identifiers referring to it.
+type binding struct {
+	name   string
+	idents []*Ident
+}
+
+type reference struct {
+	id *Ident
+	sc *scope
+}
+
+func newScope(parent *scope) *scope {
+	sc := &scope{parent: parent, decls: make(map[string]*binding), free: make(map[string]bool)}
+	if parent != nil {
+		parent.children = append(parent.children, sc)
+	}
+	return sc
+}
+
+func (sc *scope) declare(id *Ident) {
+	b, ok := sc.decls[id.Name]
+	if !ok {
+		b = &binding{name: id.Name}
+		sc.decls[id.Name] = b
+		sc.order = append(sc.order, b)
+	}
+	b.idents = append(b.idents, id)
+}
+
+func (sc *scope) fix() {
+	for s := sc; s != nil; s = s.parent {
+		s.fixed = true
+	}
+}
+
+// resolver collects the scopes of a tree and the references to resolve once
+// every hoisted declaration is known.
+type resolver struct {
+	refs []reference
+}
+
+// collect walks n, fn is the scope receiving var declarations and lex the
+// innermost scope.
+func (r *resolver) collect(n Node, fn *scope, lex *scope) {
+	switch x := n.(type) {
+	case *VarBinding:
+		fn.declare(x.Name)
+		if x.Init != nil {
+			r.collect(x.Init, fn, lex)
+		}
+		return
+	case *FuncDecl:
+		fn.declare(x.Func.Name)
+		r.function(x.Func, newScope(lex))
+		return
+	case *Func:
+		sc := newScope(lex)
+		if x.Name != nil {
+			sc.declare(x.Name)
+		}
+		r.function(x, sc)
+		return
+	case *ClassDecl:
+		fn.declare(x.Class.Name)
+		r.class(x.Class, fn, lex)
+		return
+	case *Class:
+		sc := lex
+		if x.Name != nil {
+			sc = newScope(lex)
+			sc.declare(x.Name)
+		}
+		r.class(x, fn, sc)
+		return
+	case *TryStmt:
+		r.collect(x.Block, fn, lex)
+		if x.Handler != nil {
+			sc := newScope(lex)
+			sc.declare(x.Param)
+			r.collect(x.Handler, fn, sc)
+		}
+		if x.Finalizer != nil {
+			r.collect(x.Finalizer, fn, lex)
+		}
+		return
+	case *WithStmt:
+		lex.fix()
+	case *CallExpr:
+		if id, ok := x.Callee.(*Ident); ok && id.Name == "eval" {
+			lex.fix()
+		}
+	case *MemberExpr:
+		r.collect(x.Object, fn, lex)
+		if x.Computed {
+			r.collect(x.Prop, fn, lex)
+		}
+		return
+	case *Property:
+		r.collect(x.Value, fn, lex)
+		return
+	case *LabeledStmt:
+		r.collect(x.Body, fn, lex)
+		return
+	case *ExportSpec:
+		// The exported name is not a variable
+		r.collect(x.Local, fn, lex)
+		return
+	case *BranchStmt:
+		return
+	case *Ident:
+		r.refs = append(r.refs, reference{x, lex})
+		return
+	}
+	for _, c := range children(n) {
+		r.collect(c, fn, lex)
+	}
+}
+
+// function collects the parameters and the body of a function in its scope.
+func (r *resolver) function(fn *Func, sc *scope) {
+	for _, id := range fn.Params {
+		sc.declare(id)
+	}
+	for _, st := range fn.Body.List {
+		r.collect(st, sc, sc)
+	}
+}
+
+// class collects the heritage and the methods of a class, method names are
+// not variables.
+func (r *resolver) class(c *Class, fn *scope, lex *scope) {
+	if c.Super != nil {
+		r.collect(c.Super, fn, lex)
+	}
+	for _, m := range c.Methods {
+		r.collect(m.Value, fn, lex)
+	}
+}
+
+func (r *resolver) resolve() {
+	for _, ref := range r.refs {
+		found := false
+		for sc := ref.sc; sc != nil; sc = sc.parent {
+			if b, ok := sc.decls[ref.id.Name]; ok {
+				b.idents = append(b.idents, ref.id)
+				found = true
+				break
+			}
+		}
+		if !found {
+			for sc := ref.sc; sc != nil; sc = sc.parent {
+				sc.free[ref.id.Name] = true
+			}
+		}
+	}
+}
+
+const nameChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ$_0123456789"
+
+// shortName returns the i-th short identifier: a, b, ..., _, aa, ab...
+func shortName(i int) string {
+	const first = 54 // identifiers cannot start with a digit
+	if i < first {
+		return nameChars[i : i+1]
+	}
+	i -= first
+	name := []byte{nameChars[i%first]}
+	i /= first
+	for {
+		name = append(name, nameChars[i%len(nameChars)])
+		i /= len(nameChars)
+		if i == 0 {
+			break
+		}
+		i--
+	}
+	return string(name)
+}
+
+// rename assigns short names to the bindings of sc and its children, taken
+// holds the names visible from the enclosing scopes.
+func rename(sc *scope, taken map[string]bool, names map[*Ident]string) {
+	inner := make(map[string]bool, len(taken)+len(sc.order))
+	for name := range taken {
+		inner[name] = true
+	}
+
+	if sc.parent == nil || sc.fixed {
+		for _, b := range sc.order {
+			inner[b.name] = true
+		}
+	} else {
+		// The most used bindings get the shortest names
+		bindings := append([]*binding(nil), sc.order...)
+		sort.SliceStable(bindings, func(i, j int) bool {
+			return len(bindings[i].idents) > len(bindings[j].idents)
+		})
+		next := 0
+		for _, b := range bindings {
+			name := shortName(next)
+			for inner[name] || sc.free[name] || keywords[name] {
+				next++
+				name = shortName(next)
+			}
+			next++
+			inner[name] = true
+			for _, id := range b.idents {
+				names[id] = name
+			}
+		}
+	}
+
+	for _, c := range sc.children {
+		rename(c, inner, names)
+	}
+}
+
+// Minify returns a source without comments nor needless white spaces, and
+// with its local variables renamed to short names. Global names are kept,
+// and so are the names of the functions using eval or with.
+func Minify(src string) (string, error) {
+	prog, err := Parse(src)
+	if err != nil {
+		return "", err
+	}
+
+	global := newScope(nil)
+	r := &resolver{}
+	for _, st := range prog.Body {
+		r.collect(st, global, global)
+	}
+	r.resolve()
+
+	p := &printer{names: make(map[*Ident]string)}
+	rename(global, make(map[string]bool), p.names)
+	p.node(prog)
+
+	return p.b.String(), nil
+}
@@ -0,0 +1,907 @@
+package ecma
+
+import "strings"
+
+// parser is a recursive descent parser for ECMAScript 5, and ES2015 classes.
+// The other ES2015 features fail with a *SyntaxError naming the feature.
+type parser struct {
+	lx  lexer
+	tok Token
+
+	// prevEnd is the end offset of the last consumed token
+	prevEnd int
+}
+
+// bail is used to unwind the parser on the first syntax error.
+type bail struct{ err *SyntaxError }
+
+// Parse parses an ECMAScript source and returns its syntax tree. The returned
+// error is a *SyntaxError locating the first error found.
+func Parse(src string) (prog *Program, err error) {
+	prog = &Program{src: src, lines: lineOffsets(src)}
+	p := &parser{lx: lexer{src: src}}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b, ok := r.(bail)
+			if !ok {
+				panic(r)
+			}
+			b.err.Line, b.err.Column = prog.Position(b.err.Offset)
+			prog, err = nil, b.err
+		}
+	}()
+
+	p.next()
+	for p.tok.Kind != EOF {
+		if p.is("export") {
+			prog.Body = append(prog.Body, p.parseExport())
+			continue
+		}
+		prog.Body = append(prog.Body, p.parseStatement())
+	}
+	prog.Span = Span{0, len(src)}
+
+	return prog, nil
+}
+
+func lineOffsets(src string) []int {
+	lines := []int{0}
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '\r':
+			if i+1 < len(src) && src[i+1] == '\n' {
+				i++
+			}
+			lines = append(lines, i+1)
+		case '\n':
+			lines = append(lines, i+1)
+		case 0xe2:
+			// U+2028 and U+2029
+			if strings.HasPrefix(src[i:], "\u2028") || strings.HasPrefix(src[i:], "\u2029") {
+				i += 2
+				lines = append(lines, i+1)
+			}
+		}
+	}
+	return lines
+}
+
+func (p *parser) fail(offset int, msg string) {
+	panic(bail{&SyntaxError{Offset: offset, Msg: msg}})
+}
+
+// unsupported fails on an ES2015 feature the parser does not support.
+func (p *parser) unsupported(offset int, feature string) {
+	panic(bail{&SyntaxError{Offset: offset, Msg: "unsupported ES2015 feature: " + feature, Feature: feature}})
+}
+
+// lexicalDecl fails on a let or a const declaration.
+func (p *parser) lexicalDecl() {
+	if p.is("const") {
+		p.unsupported(p.tok.Start, "const declarations")
+	}
+	if p.tok.Kind == Identifier && p.tok.Value == "let" {
+		if next := p.peek(); next.Kind == Identifier || next.Raw == "[" || next.Raw == "{" {
+			p.unsupported(p.tok.Start, "let declarations")
+		}
+	}
+}
+
+func (p *parser) unexpected() {
+	switch p.tok.Kind {
+	case EOF:
+		p.fail(p.tok.Start, "unexpected end of input")
+	case String, Number:
+		p.fail(p.tok.Start, "unexpected "+p.tok.Kind.String()+" "+p.tok.Raw)
+	default:
+		p.fail(p.tok.Start, "unexpected token "+p.tok.Raw)
+	}
+}
+
+func (p *parser) next() {
+	p.prevEnd = p.tok.End
+	tok, err := p.lx.next()
+	if err != nil {
+		panic(bail{err.(*SyntaxError)})
+	}
+	p.tok = tok
+}
+
+// peek returns the token following the current one.
+func (p *parser) peek() Token {
+	pos := p.lx.pos
+	tok, err := p.lx.next()
+	p.lx.pos = pos
+	if err != nil {
+		return Token{Kind: EOF, Start: pos, End: pos}
+	}
+	return tok
+}
+
+func (p *parser) is(value string) bool {
+	return (p.tok.Kind == Punctuator || p.tok.Kind == Keyword) && p.tok.Raw == value
+}
+
+func (p *parser) accept(value string) bool {
+	if p.is(value) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(value string) int {
+	start := p.tok.Start
+	if !p.is(value) {
+		if p.tok.Kind == EOF {
+			p.fail(p.tok.Start, "unexpected end of input, expected "+value)
+		}
+		p.fail(p.tok.Start, "expected "+value+" but found "+p.tok.Raw)
+	}
+	p.next()
+	return start
+}
+
+// semicolon consumes a statement terminator, following the automatic
+// semicolon insertion rules.
+func (p *parser) semicolon() {
+	if p.accept(";") {
+		return
+	}
+	if p.is("}") || p.tok.Kind == EOF || p.tok.NewlineBefore {
+		return
+	}
+	p.unexpected()
+}
+
+func (p *parser) ident() *Ident {
+	if p.tok.Kind != Identifier {
+		p.unexpected()
+	}
+	id := &Ident{Span{p.tok.Start, p.tok.End}, p.tok.Value}
+	p.next()
+	return id
+}
+
+// Statements
+
+func (p *parser) parseStatement() Stmt {
+	start := p.tok.Start
+	p.lexicalDecl()
+	if p.tok.Kind == Keyword {
+		switch p.tok.Raw {
+		case "var":
+			p.next()
+			decl := p.parseVarList(start, false)
+			p.semicolon()
+			decl.Stop = p.prevEnd
+			return decl
+		case "function":
+			fn := p.parseFunction(true)
+			return &FuncDecl{Span{start, fn.End()}, fn}
+		case "class":
+			class := p.parseClass(true)
+			return &ClassDecl{Span{start, class.End()}, class}
+		case "if":
+			return p.parseIf()
+		case "for":
+			return p.parseFor()
+		case "while":
+			p.next()
+			p.expect("(")
+			cond := p.parseExpression(false)
+			p.expect(")")
+			body := p.parseStatement()
+			return &WhileStmt{Span{start, body.End()}, cond, body}
+		case "do":
+			p.next()
+			body := p.parseStatement()
+			p.expect("while")
+			p.expect("(")
+			cond := p.parseExpression(false)
+			p.expect(")")
+			// The semicolon after do-while is always optional
+			p.accept(";")
+			return &DoWhileStmt{Span{start, p.prevEnd}, body, cond}
+		case "continue", "break":
+			tok := p.tok.Raw
+			p.next()
+			var label *Ident
+			if p.tok.Kind == Identifier && !p.tok.NewlineBefore {
+				label = p.ident()
+			}
+			p.semicolon()
+			return &BranchStmt{Span{start, p.prevEnd}, tok, label}
+		case "return":
+			p.next()
+			var x Expr
+			if !p.is(";") && !p.is("}") && p.tok.Kind != EOF && !p.tok.NewlineBefore {
+				x = p.parseExpression(false)
+			}
+			p.semicolon()
+			return &ReturnStmt{Span{start, p.prevEnd}, x}
+		case "throw":
+			p.next()
+			if p.tok.NewlineBefore {
+				p.fail(p.tok.Start, "illegal newline after throw")
+			}
+			x := p.parseExpression(false)
+			p.semicolon()
+			return &ThrowStmt{Span{start, p.prevEnd}, x}
+		case "try":
+			return p.parseTry()
+		case "switch":
+			return p.parseSwitch()
+		case "with":
+			p.next()
+			p.expect("(")
+			obj := p.parseExpression(false)
+			p.expect(")")
+			body := p.parseStatement()
+			return &WithStmt{Span{start, body.End()}, obj, body}
+		case "debugger":
+			p.next()
+			p.semicolon()
+			return &DebuggerStmt{Span{start, p.prevEnd}}
+		}
+	}
+	if p.is("{") {
+		return p.parseBlock()
+	}
+	if p.is(";") {
+		p.next()
+		return &EmptyStmt{Span{start, p.prevEnd}}
+	}
+	if p.tok.Kind == Identifier && p.peek().Raw == ":" {
+		label := p.ident()
+		p.expect(":")
+		body := p.parseStatement()
+		return &LabeledStmt{Span{start, body.End()}, label, body}
+	}
+
+	x := p.parseExpression(false)
+	p.semicolon()
+	return &ExprStmt{Span{start, p.prevEnd}, x}
+}
+
+// parseExport parses an export declaration, only allowed at the top-level.
+func (p *parser) parseExport() Stmt {
+	start := p.expect("export")
+	decl := &ExportDecl{}
+	switch {
+	case p.is("var"), p.is("function"), p.is("class"):
+		decl.Decl = p.parseStatement()
+	case p.accept("default"):
+		decl.Default = p.parseAssign(false)
+		p.semicolon()
+	case p.accept("{"):
+		decl.Specs = make([]*ExportSpec, 0)
+		for !p.accept("}") {
+			spec := &ExportSpec{Local: p.ident()}
+			spec.Exported = spec.Local
+			if p.tok.Kind == Identifier && p.tok.Value == "as" {
+				p.next()
+				if p.tok.Kind != Identifier && p.tok.Kind != Keyword {
+					p.unexpected()
+				}
+				spec.Exported = &Ident{Span{p.tok.Start, p.tok.End}, p.tok.Value}
+				p.next()
+			}
+			spec.Span = Span{spec.Local.Start, p.prevEnd}
+			decl.Specs = append(decl.Specs, spec)
+			if !p.is("}") {
+				p.expect(",")
+			}
+		}
+		p.semicolon()
+	default:
+		p.unexpected()
+	}
+	decl.Span = Span{start, p.prevEnd}
+	return decl
+}
+
+func (p *parser) parseBlock() *BlockStmt {
+	start := p.expect("{")
+	list := make([]Stmt, 0)
+	for !p.is("}") {
+		if p.tok.Kind == EOF {
+			p.unexpected()
+		}
+		list = append(list, p.parseStatement())
+	}
+	p.next()
+	return &BlockStmt{Span{start, p.prevEnd}, list}
+}
+
+// parseVarList parses the declarators following the var keyword.
+func (p *parser) parseVarList(start int, noIn bool) *VarDecl {
+	decl := &VarDecl{}
+	for {
+		if p.is("[") || p.is("{") {
+			p.unsupported(p.tok.Start, "destructuring")
+		}
+		name := p.ident()
+		b := &VarBinding{Name: name}
+		if p.accept("=") {
+			b.Init = p.parseAssign(noIn)
+		}
+		b.Span = Span{name.Start, p.prevEnd}
+		decl.List = append(decl.List, b)
+		if !p.accept(",") {
+			break
+		}
+	}
+	decl.Span = Span{start, p.prevEnd}
+	return decl
+}
+
+func (p *parser) parseIf() Stmt {
+	start := p.expect("if")
+	p.expect("(")
+	cond := p.parseExpression(false)
+	p.expect(")")
+	then := p.parseStatement()
+	var els Stmt
+	if p.accept("else") {
+		els = p.parseStatement()
+	}
+	return &IfStmt{Span{start, p.prevEnd}, cond, then, els}
+}
+
+func (p *parser) parseFor() Stmt {
+	start := p.expect("for")
+	p.expect("(")
+
+	var init Node
+	p.lexicalDecl()
+	if p.is("var") {
+		varStart := p.tok.Start
+		p.next()
+		decl := p.parseVarList(varStart, true)
+		init = decl
+		if len(decl.List) == 1 && p.accept("in") {
+			return p.parseForInRest(start, decl)
+		}
+		p.forOf()
+	} else if !p.is(";") {
+		x := p.parseExpression(true)
+		init = x
+		p.forOf()
+		if p.accept("in") {
+			if !isAssignable(x) {
+				p.fail(x.Pos(), "invalid left-hand side in for-in")
+			}
+			return p.parseForInRest(start, x)
+		}
+	}
+
+	p.expect(";")
+	var cond, update Expr
+	if !p.is(";") {
+		cond = p.parseExpression(false)
+	}
+	p.expect(";")
+	if !p.is(")") {
+		update = p.parseExpression(false)
+	}
+	p.expect(")")
+	body := p.parseStatement()
+	return &ForStmt{Span{start, body.End()}, init, cond, update, body}
+}
+
+// forOf fails on the of of a for-of loop.
+func (p *parser) forOf() {
+	if p.tok.Kind == Identifier && p.tok.Value == "of" {
+		p.unsupported(p.tok.Start, "for...of loops")
+	}
+}
+
+func (p *parser) parseForInRest(start int, left Node) Stmt {
+	right := p.parseExpression(false)
+	p.expect(")")
+	body := p.parseStatement()
+	return &ForInStmt{Span{start, body.End()}, left, right, body}
+}
+
+func (p *parser) parseTry() Stmt {
+	start := p.expect("try")
+	s := &TryStmt{Block: p.parseBlock()}
+	if p.accept("catch") {
+		p.expect("(")
+		s.Param = p.ident()
+		p.expect(")")
+		s.Handler = p.parseBlock()
+	}
+	if p.accept("finally") {
+		s.Finalizer = p.parseBlock()
+	}
+	if s.Handler == nil && s.Finalizer == nil {
+		p.fail(p.tok.Start, "missing catch or finally after try")
+	}
+	s.Span = Span{start, p.prevEnd}
+	return s
+}
+
+func (p *parser) parseSwitch() Stmt {
+	start := p.expect("switch")
+	p.expect("(")
+	tag := p.parseExpression(false)
+	p.expect(")")
+	p.expect("{")
+	s := &SwitchStmt{Tag: tag}
+	hasDefault := false
+	for !p.accept("}") {
+		cStart := p.tok.Start
+		c := &CaseClause{}
+		if p.accept("default") {
+			if hasDefault {
+				p.fail(cStart, "more than one default clause in switch")
+			}
+			hasDefault = true
+		} else {
+			p.expect("case")
+			c.Test = p.parseExpression(false)
+		}
+		p.expect(":")
+		for !p.is("case") && !p.is("default") && !p.is("}") {
+			if p.tok.Kind == EOF {
+				p.unexpected()
+			}
+			c.Body = append(c.Body, p.parseStatement())
+		}
+		c.Span = Span{cStart, p.prevEnd}
+		s.Cases = append(s.Cases, c)
+	}
+	s.Span = Span{start, p.prevEnd}
+	return s
+}
+
+// parseFunction parses a function from the function keyword, the name is
+// required for declarations.
+func (p *parser) parseFunction(decl bool) *Func {
+	start := p.expect("function")
+	if p.is("*") {
+		p.unsupported(p.tok.Start, "generators")
+	}
+	fn := &Func{}
+	if p.tok.Kind == Identifier {
+		fn.Name = p.ident()
+	} else if decl {
+		p.unexpected()
+	}
+	p.parseFunctionRest(fn)
+	fn.Span = Span{start, p.prevEnd}
+	return fn
+}
+
+// parseFunctionRest parses the parameters and the body of a function.
+func (p *parser) parseFunctionRest(fn *Func) {
+	fn.Lparen = p.expect("(")
+	fn.Params = make([]*Ident, 0)
+	for !p.is(")") {
+		switch {
+		case p.is("..."):
+			p.unsupported(p.tok.Start, "rest parameters")
+		case p.is("[") || p.is("{"):
+			p.unsupported(p.tok.Start, "destructuring")
+		}
+		fn.Params = append(fn.Params, p.ident())
+		if p.is("=") {
+			p.unsupported(p.tok.Start, "default parameters")
+		}
+		if !p.is(")") {
+			p.expect(",")
+		}
+	}
+	fn.Rparen = p.expect(")")
+	fn.Body = p.parseBlock()
+}
+
+// parseClass parses a class from the class keyword, the name is required for
+// declarations.
+func (p *parser) parseClass(decl bool) *Class {
+	start := p.expect("class")
+	class := &Class{Methods: make([]*Method, 0)}
+	if p.tok.Kind == Identifier {
+		class.Name = p.ident()
+	} else if decl {
+		p.unexpected()
+	}
+	if p.accept("extends") {
+		class.Super = p.parseLeftHandSide()
+	}
+	p.expect("{")
+	hasConstructor := false
+	for !p.accept("}") {
+		if p.accept(";") {
+			continue
+		}
+		m := p.parseMethod()
+		if m.Kind == "constructor" {
+			if hasConstructor {
+				p.fail(m.Pos(), "duplicate constructor in class")
+			}
+			hasConstructor = true
+		}
+		class.Methods = append(class.Methods, m)
+	}
+	class.Span = Span{start, p.prevEnd}
+	return class
+}
+
+// parseMethod parses a method definition of a class body.
+func (p *parser) parseMethod() *Method {
+	start := p.tok.Start
+	m := &Method{Kind: "method"}
+	if p.tok.Kind == Identifier && p.tok.Value == "static" && p.peek().Raw != "(" {
+		m.Static = true
+		p.next()
+	}
+	if p.tok.Kind == Identifier && (p.tok.Value == "get" || p.tok.Value == "set") && p.peek().Raw != "(" {
+		m.Kind = p.tok.Value
+		p.next()
+	}
+	m.Key = p.propertyKey()
+	if !m.Static && m.Key.Value == "constructor" {
+		if m.Kind != "method" {
+			p.fail(m.Key.Pos(), "class constructor may not be an accessor")
+		}
+		m.Kind = "constructor"
+	}
+	m.Value = &Func{}
+	p.parseFunctionRest(m.Value)
+	m.Value.Span = Span{m.Key.Start, p.prevEnd}
+	m.Span = Span{start, p.prevEnd}
+	return m
+}
+
+// Expressions
+
+func (p *parser) parseExpression(noIn bool) Expr {
+	x := p.parseAssign(noIn)
+	if !p.is(",") {
+		return x
+	}
+	seq := &SeqExpr{List: []Expr{x}}
+	for p.accept(",") {
+		seq.List = append(seq.List, p.parseAssign(noIn))
+	}
+	seq.Span = Span{x.Pos(), p.prevEnd}
+	return seq
+}
+
+var assignOps = map[string]bool{
+	"=": true, "+=": true, "-=": true, "*=": true, "/=": true, "%=": true,
+	"<<=": true, ">>=": true, ">>>=": true, "&=": true, "|=": true, "^=": true,
+}
+
+func isAssignable(x Expr) bool {
+	switch Unparen(x).(type) {
+	case *Ident, *MemberExpr:
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseAssign(noIn bool) Expr {
+	left := p.parseConditional(noIn)
+	if p.is("=>") {
+		p.unsupported(p.tok.Start, "arrow functions")
+	}
+	if p.tok.Kind == Punctuator && assignOps[p.tok.Raw] {
+		switch Unparen(left).(type) {
+		case *ArrayLit, *ObjectLit:
+			p.unsupported(left.Pos(), "destructuring")
+		}
+		if !isAssignable(left) {
+			p.fail(left.Pos(), "invalid assignment target")
+		}
+		op := p.tok.Raw
+		p.next()
+		right := p.parseAssign(noIn)
+		return &AssignExpr{Span{left.Pos(), right.End()}, op, left, right}
+	}
+	return left
+}
+
+func (p *parser) parseConditional(noIn bool) Expr {
+	test := p.parseBinary(1, noIn)
+	if !p.accept("?") {
+		return test
+	}
+	then := p.parseAssign(false)
+	p.expect(":")
+	els := p.parseAssign(noIn)
+	return &CondExpr{Span{test.Pos(), els.End()}, test, then, els}
+}
+
+// BinaryPrecedence returns the precedence of a binary operator, 0 if op is
+// not one.
+func BinaryPrecedence(op string) int {
+	switch op {
+	case "||":
+		return 1
+	case "&&":
+		return 2
+	case "|":
+		return 3
+	case "^":
+		return 4
+	case "&":
+		return 5
+	case "==", "!=", "===", "!==":
+		return 6
+	case "<", ">", "<=", ">=", "instanceof", "in":
+		return 7
+	case "<<", ">>", ">>>":
+		return 8
+	case "+", "-":
+		return 9
+	case "*", "/", "%":
+		return 10
+	}
+	return 0
+}
+
+func (p *parser) binaryOp(noIn bool) (string, int) {
+	if p.tok.Kind != Punctuator && p.tok.Kind != Keyword {
+		return "", 0
+	}
+	if noIn && p.tok.Raw == "in" {
+		return "", 0
+	}
+	return p.tok.Raw, BinaryPrecedence(p.tok.Raw)
+}
+
+func (p *parser) parseBinary(minPrec int, noIn bool) Expr {
+	x := p.parseUnary()
+	for {
+		op, prec := p.binaryOp(noIn)
+		if prec < minPrec || prec == 0 {
+			return x
+		}
+		p.next()
+		y := p.parseBinary(prec+1, noIn)
+		x = &BinaryExpr{Span{x.Pos(), y.End()}, op, x, y}
+	}
+}
+
+func (p *parser) parseUnary() Expr {
+	start := p.tok.Start
+	switch {
+	case p.is("++") || p.is("--"):
+		op := p.tok.Raw
+		p.next()
+		x := p.parseUnary()
+		if !isAssignable(x) {
+			p.fail(x.Pos(), "invalid operand for "+op)
+		}
+		return &UpdateExpr{Span{start, x.End()}, op, true, x}
+	case p.is("delete") || p.is("void") || p.is("typeof") || p.is("+") || p.is("-") || p.is("~") || p.is("!"):
+		op := p.tok.Raw
+		p.next()
+		x := p.parseUnary()
+		return &UnaryExpr{Span{start, x.End()}, op, x}
+	}
+
+	x := p.parseLeftHandSide()
+	if (p.is("++") || p.is("--")) && !p.tok.NewlineBefore {
+		if !isAssignable(x) {
+			p.fail(x.Pos(), "invalid operand for "+p.tok.Raw)
+		}
+		op := p.tok.Raw
+		p.next()
+		return &UpdateExpr{Span{start, p.prevEnd}, op, false, x}
+	}
+	return x
+}
+
+func (p *parser) parseArguments() []Expr {
+	p.expect("(")
+	args := make([]Expr, 0)
+	for !p.is(")") {
+		args = append(args, p.parseAssign(false))
+		if !p.is(")") {
+			p.expect(",")
+		}
+	}
+	p.next()
+	return args
+}
+
+// parseLeftHandSide parses member accesses, calls and new expressions.
+func (p *parser) parseLeftHandSide() Expr {
+	var x Expr
+	if p.is("new") {
+		x = p.parseNew()
+	} else {
+		x = p.parsePrimary()
+	}
+	for {
+		switch {
+		case p.is("."), p.is("["):
+			x = p.parseMember(x)
+		case p.is("("):
+			args := p.parseArguments()
+			x = &CallExpr{Span{x.Pos(), p.prevEnd}, x, args}
+		default:
+			return x
+		}
+	}
+}
+
+func (p *parser) parseMember(x Expr) Expr {
+	if p.accept(".") {
+		if p.tok.Kind != Identifier && p.tok.Kind != Keyword {
+			p.unexpected()
+		}
+		prop := &Ident{Span{p.tok.Start, p.tok.End}, p.tok.Value}
+		p.next()
+		return &MemberExpr{Span{x.Pos(), p.prevEnd}, x, prop, false}
+	}
+	p.expect("[")
+	prop := p.parseExpression(false)
+	p.expect("]")
+	return &MemberExpr{Span{x.Pos(), p.prevEnd}, x, prop, true}
+}
+
+func (p *parser) parseNew() Expr {
+	start := p.expect("new")
+	var callee Expr
+	if p.is("new") {
+		callee = p.parseNew()
+	} else {
+		callee = p.parsePrimary()
+	}
+	for p.is(".") || p.is("[") {
+		callee = p.parseMember(callee)
+	}
+	args := make([]Expr, 0)
+	if p.is("(") {
+		args = p.parseArguments()
+	}
+	return &NewExpr{Span{start, p.prevEnd}, callee, args}
+}
+
+func (p *parser) parsePrimary() Expr {
+	tok := p.tok
+	span := Span{tok.Start, tok.End}
+	switch tok.Kind {
+	case Identifier:
+		return p.ident()
+	case Number, String:
+		p.next()
+		return &Literal{span, tok.Kind, tok.Raw, tok.Value}
+	case Keyword:
+		switch tok.Raw {
+		case "this":
+			p.next()
+			return &ThisExpr{span}
+		case "null", "true", "false":
+			p.next()
+			return &Literal{span, Keyword, tok.Raw, tok.Raw}
+		case "function":
+			return p.parseFunction(false)
+		case "class":
+			return p.parseClass(false)
+		case "super":
+			p.next()
+			if !p.is("(") && !p.is(".") && !p.is("[") {
+				p.fail(tok.Start, "unexpected super")
+			}
+			return &SuperExpr{span}
+		case "import":
+			p.next()
+			p.expect(".")
+			if p.tok.Kind != Identifier || p.tok.Value != "meta" {
+				p.unexpected()
+			}
+			p.next()
+			return &ImportMeta{Span{tok.Start, p.prevEnd}}
+		}
+	case Punctuator:
+		switch tok.Raw {
+		case "(":
+			p.next()
+			if p.is(")") && p.peek().Raw == "=>" {
+				p.unsupported(tok.Start, "arrow functions")
+			}
+			x := p.parseExpression(false)
+			p.expect(")")
+			return &ParenExpr{Span{tok.Start, p.prevEnd}, x}
+		case "[":
+			return p.parseArray()
+		case "{":
+			return p.parseObject()
+		case "...":
+			p.unsupported(tok.Start, "spread elements")
+		case "/", "/=":
+			re, err := p.lx.scanRegExp(tok.Start)
+			if err != nil {
+				panic(bail{err.(*SyntaxError)})
+			}
+			p.tok = re
+			p.next()
+			return &Literal{Span{re.Start, re.End}, RegExp, re.Raw, re.Raw}
+		}
+	}
+	p.unexpected()
+	return nil
+}
+
+func (p *parser) parseArray() Expr {
+	start := p.expect("[")
+	list := make([]Expr, 0)
+	for !p.is("]") {
+		if p.is(",") {
+			p.next()
+			list = append(list, nil)
+			continue
+		}
+		list = append(list, p.parseAssign(false))
+		if !p.is("]") {
+			p.expect(",")
+		}
+	}
+	p.next()
+	return &ArrayLit{Span{start, p.prevEnd}, list}
+}
+
+// propertyKey parses an object literal key, names are stored as strings.
+func (p *parser) propertyKey() *Literal {
+	tok := p.tok
+	if p.is("[") {
+		p.unsupported(tok.Start, "computed property names")
+	}
+	switch tok.Kind {
+	case Identifier, Keyword, String, Number:
+		p.next()
+		kind := tok.Kind
+		if kind != Number {
+			kind = String
+		}
+		return &Literal{Span{tok.Start, tok.End}, kind, tok.Raw, tok.Value}
+	}
+	p.unexpected()
+	return nil
+}
+
+func (p *parser) parseObject() Expr {
+	start := p.expect("{")
+	props := make([]*Property, 0)
+	for !p.is("}") {
+		pStart := p.tok.Start
+		prop := &Property{Kind: "init"}
+		if p.tok.Kind == Identifier && (p.tok.Value == "get" || p.tok.Value == "set") {
+			if next := p.peek(); next.Raw != ":" && next.Raw != "," && next.Raw != "}" {
+				prop.Kind = p.tok.Value
+				p.next()
+			}
+		}
+		prop.Key = p.propertyKey()
+		switch {
+		case prop.Kind == "init" && (p.is(",") || p.is("}")):
+			p.unsupported(prop.Key.Pos(), "shorthand properties")
+		case prop.Kind == "init" && p.is("("):
+			p.unsupported(prop.Key.Pos(), "shorthand methods")
+		}
+		if prop.Kind == "init" {
+			p.expect(":")
+			prop.Value = p.parseAssign(false)
+		} else {
+			fn := &Func{}
+			p.parseFunctionRest(fn)
+			fn.Span = Span{prop.Key.Start, p.prevEnd}
+			prop.Value = fn
+		}
+		prop.Span = Span{pStart, p.prevEnd}
+		props = append(props, prop)
+		if !p.is("}") {
+			p.expect(",")
+		}
+	}
+	p.next()
+	return &ObjectLit{Span{start, p.prevEnd}, props}
+}
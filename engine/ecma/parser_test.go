@@ -0,0 +1,174 @@
+package ecma_test
+
+import (
+	"testing"
+
+	"github.com/woobleio/wooblizer/engine/ecma"
+)
+
+func TestParse(t *testing.T) {
+	valid := []string{
+		`var a = /x[/]y/g.test(b) / 2;`,
+		"var x = a\n++b",
+		`x = {get a(){return 1}, set a(v){}, 'b':2, 3:4,}`,
+		`for (var k in o) if (k) continue; else break;`,
+		`lbl: for(;;){break lbl}`,
+		`x = a ? b : c, d`,
+		`new new A()()`,
+		`try { a() } catch (e) { throw e } finally { b() }`,
+		`switch (a) { case 1: b(); break; default: c() }`,
+		`do x++; while (x < 10) y()`,
+		`class A extends B.C { constructor(a) { super(a); this.a = a } static get() {} get b() { return super.b }; set b(v) {} }`,
+		`x = class { 'constructor'() {} static static() {} }`,
+		`base = new URL('.', import.meta.url).href`,
+	}
+	for _, src := range valid {
+		if _, err := ecma.Parse(src); err != nil {
+			t.Errorf("Parse %q : Unexpected error %s", src, err)
+		}
+	}
+
+	prog, err := ecma.Parse("var a = 1;\nfunction b(c) { return c }")
+	if err != nil {
+		t.Fatalf("Parse : Unexpected error %s", err)
+	}
+	if len(prog.Body) != 2 {
+		t.Fatalf("Parse : Expected 2 statements, got %d", len(prog.Body))
+	}
+	fn, ok := prog.Body[1].(*ecma.FuncDecl)
+	if !ok || fn.Func.Name.Name != "b" || len(fn.Func.Params) != 1 {
+		t.Error("Parse : Function declaration expected")
+	}
+	if line, col := prog.Position(fn.Pos()); line != 2 || col != 1 {
+		t.Errorf("Position : Expected 2:1, got %d:%d", line, col)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		src       string
+		line, col int
+	}{
+		{"var a = 1 +", 1, 12},
+		{"x = 'abc", 1, 5},
+		{"function f() {\n  return 1\n  var = 2;\n}", 3, 7},
+		{"/* unterminated", 1, 1},
+		{"a = 1 b = 2", 1, 7},
+		{"class A { constructor() {}\n constructor() {} }", 2, 2},
+		{"class { }", 1, 7},
+		{"x = super", 1, 5},
+		{"x = import.url", 1, 12},
+	}
+	for _, test := range tests {
+		_, err := ecma.Parse(test.src)
+		se, ok := err.(*ecma.SyntaxError)
+		if !ok {
+			t.Errorf("Parse %q : Expected a syntax error, got %v", test.src, err)
+			continue
+		}
+		if se.Line != test.line || se.Column != test.col {
+			t.Errorf("Parse %q : Expected error at %d:%d, got %s", test.src, test.line, test.col, se)
+		}
+	}
+}
+
+func TestParseUnsupported(t *testing.T) {
+	tests := []struct {
+		src, feature string
+		col          int
+	}{
+		{"class A { m() { let total = 0; } }", "let declarations", 17},
+		{"for (const k in o) {}", "const declarations", 6},
+		{"f(a => a)", "arrow functions", 5},
+		{"f(() => 1)", "arrow functions", 3},
+		{"x = `a${b}`", "template literals", 5},
+		{"var {a, b} = o", "destructuring", 5},
+		{"[a, b] = [b, a]", "destructuring", 1},
+		{"function f(a, b = 1) {}", "default parameters", 17},
+		{"function f(...args) {}", "rest parameters", 12},
+		{"f(...args)", "spread elements", 3},
+		{"for (var v of list) {}", "for...of loops", 12},
+		{"x = {a, b}", "shorthand properties", 6},
+		{"x = {m() {}}", "shorthand methods", 6},
+		{"x = {[k]: 1}", "computed property names", 6},
+		{"function* g() {}", "generators", 9},
+	}
+	for _, test := range tests {
+		_, err := ecma.Parse(test.src)
+		se, ok := err.(*ecma.SyntaxError)
+		if !ok || se.Feature != test.feature || se.Column != test.col {
+			t.Errorf("Parse %q : Expected unsupported %s at 1:%d, got %v", test.src, test.feature, test.col, err)
+		}
+	}
+
+	// let is an identifier in ES5
+	if _, err := ecma.Parse("var let = 1; let = let + 1;"); err != nil {
+		t.Errorf("Parse : Unexpected error %s", err)
+	}
+}
+
+func TestMinify(t *testing.T) {
+	src := `// Comment
+var global = function (first, second) {
+	/* Block comment */
+	var local = first + +second;
+	try {
+		err();
+	} catch (err) {
+		return local - -err;
+	}
+	return typeof local === "number" ? 1..toString() : [local,,];
+};
+function withEval(code) { var kept = 1; return eval(code); }`
+
+	min, err := ecma.Minify(src)
+	if err != nil {
+		t.Fatalf("Minify : Unexpected error %s", err)
+	}
+
+	expected := `var global=function(b,c){var a=b+ +c;try{err();}catch(d){return a- -d;}return typeof a==="number"?1..toString():[a,,];};function withEval(code){var kept=1;return eval(code);}`
+	if min != expected {
+		t.Errorf("Minify : Unexpected source %s", min)
+	}
+	if _, err := ecma.Parse(min); err != nil {
+		t.Errorf("Minify : Output does not parse, error %s", err)
+	}
+}
+
+func TestMinifyRegExpAndContinuation(t *testing.T) {
+	src := "var r = /a/ instanceof RegExp, s = /x/g in o, t = /y/ in o;\nvar l = 'first \\\nsecond', m = \"\\\\\", n = {'a\\\r\nb': 1};"
+
+	min, err := ecma.Minify(src)
+	if err != nil {
+		t.Fatalf("Minify : Unexpected error %s", err)
+	}
+
+	expected := `var r=/a/ instanceof RegExp,s=/x/g in o,t=/y/ in o;var l='first second',m="\\",n={'ab':1};`
+	if min != expected {
+		t.Errorf("Minify : Unexpected source %s", min)
+	}
+	if _, err := ecma.Parse(min); err != nil {
+		t.Errorf("Minify : Output does not parse, error %s", err)
+	}
+}
+
+func TestMinifyClass(t *testing.T) {
+	src := `var f = function (param) {
+	var Local = class Named extends Base { constructor(value) { super(value); this.n = Named; } static get size() { return 1; } };
+	class Decl { m(other) { return new Local(other); } }
+	return new Decl().m(param);
+};`
+
+	min, err := ecma.Minify(src)
+	if err != nil {
+		t.Fatalf("Minify : Unexpected error %s", err)
+	}
+
+	expected := `var f=function(a){var b=class d extends Base{constructor(e){super(e);this.n=d;}static get size(){return 1;}};class c{m(d){return new b(d);}}return new c().m(a);};`
+	if min != expected {
+		t.Errorf("Minify : Unexpected source %s", min)
+	}
+	if _, err := ecma.Parse(min); err != nil {
+		t.Errorf("Minify : Output does not parse, error %s", err)
+	}
+}
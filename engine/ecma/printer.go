@@ -0,0 +1,448 @@
+package ecma
+
+import "strings"
+
+// printer writes a tree back as compact source, without comments nor
+// needless white spaces. Parentheses of the source are kept so the output has
+// the same structure.
+type printer struct {
+	b strings.Builder
+
+	// names maps identifiers to their new name
+	names map[*Ident]string
+
+	// regexp is set when the last token is a regular expression, its flags
+	// would take an identifier written after it
+	regexp bool
+}
+
+// Print returns the compact source of a node.
+func Print(n Node) string {
+	p := &printer{}
+	p.node(n)
+	return p.b.String()
+}
+
+func isIdentByte(c byte) bool {
+	return c == '$' || c == '_' || c == '\\' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// write appends a token, separated from the previous one by a space when
+// they would otherwise merge.
+func (p *printer) write(tok string) {
+	if tok == "" {
+		return
+	}
+	if p.b.Len() > 0 {
+		s := p.b.String()
+		last, first := s[len(s)-1], tok[0]
+		switch {
+		case isIdentByte(last) && isIdentByte(first),
+			p.regexp && isIdentByte(first),
+			last == '+' && first == '+',
+			last == '-' && first == '-',
+			last == '/' && (first == '/' || first == '*'),
+			last == '<' && first == '!':
+			p.b.WriteByte(' ')
+		}
+	}
+	p.b.WriteString(tok)
+	p.regexp = false
+}
+
+// literal writes the raw source of a literal or a property key, the line
+// continuations of a string are dropped so it stays on one line.
+func (p *printer) literal(raw string, kind TokenKind) {
+	if raw != "" && (raw[0] == '"' || raw[0] == '\'') {
+		raw = stripContinuations(raw)
+	}
+	p.write(raw)
+	p.regexp = kind == RegExp
+}
+
+// stripContinuations removes the backslashes followed by a line terminator
+// from the raw source of a string.
+func stripContinuations(raw string) string {
+	if !strings.ContainsAny(raw, "\n\r\u2028\u2029") {
+		return raw
+	}
+	var b strings.Builder
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' {
+			b.WriteByte(raw[i])
+			continue
+		}
+		rest := raw[i+1:]
+		switch {
+		case strings.HasPrefix(rest, "\r\n"):
+			i += 2
+		case strings.HasPrefix(rest, "\n"), strings.HasPrefix(rest, "\r"):
+			i++
+		case strings.HasPrefix(rest, "\u2028"), strings.HasPrefix(rest, "\u2029"):
+			i += len("\u2028")
+		default:
+			b.WriteString(raw[i : i+2])
+			i++
+		}
+	}
+	return b.String()
+}
+
+func (p *printer) ident(id *Ident) {
+	if name, ok := p.names[id]; ok {
+		p.write(name)
+		return
+	}
+	p.write(id.Name)
+}
+
+func (p *printer) node(n Node) {
+	switch x := n.(type) {
+	case *Program:
+		p.stmts(x.Body)
+	case Stmt:
+		p.stmt(x)
+	case Expr:
+		p.expr(x)
+	}
+}
+
+func (p *printer) stmts(list []Stmt) {
+	for _, st := range list {
+		p.stmt(st)
+	}
+}
+
+func (p *printer) varList(decl *VarDecl) {
+	p.write("var")
+	for i, b := range decl.List {
+		if i > 0 {
+			p.write(",")
+		}
+		p.ident(b.Name)
+		if b.Init != nil {
+			p.write("=")
+			p.expr(b.Init)
+		}
+	}
+}
+
+func (p *printer) stmt(st Stmt) {
+	switch x := st.(type) {
+	case *VarDecl:
+		p.varList(x)
+		p.write(";")
+	case *FuncDecl:
+		p.function(x.Func)
+	case *ClassDecl:
+		p.class(x.Class)
+	case *ExprStmt:
+		p.expr(x.X)
+		p.write(";")
+	case *BlockStmt:
+		p.write("{")
+		p.stmts(x.List)
+		p.write("}")
+	case *EmptyStmt:
+		p.write(";")
+	case *IfStmt:
+		p.write("if")
+		p.write("(")
+		p.expr(x.Cond)
+		p.write(")")
+		p.stmt(x.Then)
+		if x.Else != nil {
+			p.write("else")
+			p.stmt(x.Else)
+		}
+	case *ForStmt:
+		p.write("for")
+		p.write("(")
+		switch init := x.Init.(type) {
+		case *VarDecl:
+			p.varList(init)
+		case Expr:
+			p.expr(init)
+		}
+		p.write(";")
+		if x.Cond != nil {
+			p.expr(x.Cond)
+		}
+		p.write(";")
+		if x.Update != nil {
+			p.expr(x.Update)
+		}
+		p.write(")")
+		p.stmt(x.Body)
+	case *ForInStmt:
+		p.write("for")
+		p.write("(")
+		switch left := x.Left.(type) {
+		case *VarDecl:
+			p.varList(left)
+		case Expr:
+			p.expr(left)
+		}
+		p.write("in")
+		p.expr(x.Right)
+		p.write(")")
+		p.stmt(x.Body)
+	case *WhileStmt:
+		p.write("while")
+		p.write("(")
+		p.expr(x.Cond)
+		p.write(")")
+		p.stmt(x.Body)
+	case *DoWhileStmt:
+		p.write("do")
+		p.stmt(x.Body)
+		p.write("while")
+		p.write("(")
+		p.expr(x.Cond)
+		p.write(")")
+		p.write(";")
+	case *ReturnStmt:
+		p.write("return")
+		if x.Result != nil {
+			p.expr(x.Result)
+		}
+		p.write(";")
+	case *BranchStmt:
+		p.write(x.Tok)
+		if x.Label != nil {
+			p.write(x.Label.Name)
+		}
+		p.write(";")
+	case *ThrowStmt:
+		p.write("throw")
+		p.expr(x.X)
+		p.write(";")
+	case *TryStmt:
+		p.write("try")
+		p.stmt(x.Block)
+		if x.Handler != nil {
+			p.write("catch")
+			p.write("(")
+			p.ident(x.Param)
+			p.write(")")
+			p.stmt(x.Handler)
+		}
+		if x.Finalizer != nil {
+			p.write("finally")
+			p.stmt(x.Finalizer)
+		}
+	case *SwitchStmt:
+		p.write("switch")
+		p.write("(")
+		p.expr(x.Tag)
+		p.write(")")
+		p.write("{")
+		for _, c := range x.Cases {
+			if c.Test != nil {
+				p.write("case")
+				p.expr(c.Test)
+			} else {
+				p.write("default")
+			}
+			p.write(":")
+			p.stmts(c.Body)
+		}
+		p.write("}")
+	case *LabeledStmt:
+		p.write(x.Label.Name)
+		p.write(":")
+		p.stmt(x.Body)
+	case *WithStmt:
+		p.write("with")
+		p.write("(")
+		p.expr(x.Object)
+		p.write(")")
+		p.stmt(x.Body)
+	case *DebuggerStmt:
+		p.write("debugger")
+		p.write(";")
+	case *ExportDecl:
+		p.write("export")
+		switch {
+		case x.Decl != nil:
+			p.stmt(x.Decl)
+		case x.Default != nil:
+			p.write("default")
+			p.expr(x.Default)
+			p.write(";")
+		default:
+			p.write("{")
+			for i, spec := range x.Specs {
+				if i > 0 {
+					p.write(",")
+				}
+				p.ident(spec.Local)
+				if spec.Exported.Name != spec.Local.Name || p.names[spec.Local] != "" {
+					p.write("as")
+					p.write(spec.Exported.Name)
+				}
+			}
+			p.write("}")
+			p.write(";")
+		}
+	}
+}
+
+func (p *printer) function(fn *Func) {
+	p.write("function")
+	if fn.Name != nil {
+		p.ident(fn.Name)
+	}
+	p.params(fn.Params)
+	p.stmt(fn.Body)
+}
+
+func (p *printer) class(c *Class) {
+	p.write("class")
+	if c.Name != nil {
+		p.ident(c.Name)
+	}
+	if c.Super != nil {
+		p.write("extends")
+		p.expr(c.Super)
+	}
+	p.write("{")
+	for _, m := range c.Methods {
+		if m.Static {
+			p.write("static")
+		}
+		if m.Kind == "get" || m.Kind == "set" {
+			p.write(m.Kind)
+		}
+		p.literal(m.Key.Raw, m.Key.Kind)
+		p.params(m.Value.Params)
+		p.stmt(m.Value.Body)
+	}
+	p.write("}")
+}
+
+func (p *printer) params(params []*Ident) {
+	p.write("(")
+	for i, id := range params {
+		if i > 0 {
+			p.write(",")
+		}
+		p.ident(id)
+	}
+	p.write(")")
+}
+
+func (p *printer) exprs(list []Expr) {
+	for i, x := range list {
+		if i > 0 {
+			p.write(",")
+		}
+		p.expr(x)
+	}
+}
+
+func (p *printer) expr(x Expr) {
+	switch x := x.(type) {
+	case *Ident:
+		p.ident(x)
+	case *Literal:
+		p.literal(x.Raw, x.Kind)
+	case *ThisExpr:
+		p.write("this")
+	case *ArrayLit:
+		p.write("[")
+		for i, el := range x.List {
+			if el != nil {
+				p.expr(el)
+			}
+			if i < len(x.List)-1 || el == nil {
+				p.write(",")
+			}
+		}
+		p.write("]")
+	case *ObjectLit:
+		p.write("{")
+		for i, prop := range x.Props {
+			if i > 0 {
+				p.write(",")
+			}
+			if prop.Kind != "init" {
+				p.write(prop.Kind)
+				p.literal(prop.Key.Raw, prop.Key.Kind)
+				fn := prop.Value.(*Func)
+				p.params(fn.Params)
+				p.stmt(fn.Body)
+				continue
+			}
+			p.literal(prop.Key.Raw, prop.Key.Kind)
+			p.write(":")
+			p.expr(prop.Value)
+		}
+		p.write("}")
+	case *Func:
+		p.function(x)
+	case *Class:
+		p.class(x)
+	case *SuperExpr:
+		p.write("super")
+	case *ImportMeta:
+		p.write("import.meta")
+	case *UnaryExpr:
+		p.write(x.Op)
+		p.expr(x.X)
+	case *UpdateExpr:
+		if x.Prefix {
+			p.write(x.Op)
+			p.expr(x.X)
+		} else {
+			p.expr(x.X)
+			p.write(x.Op)
+		}
+	case *BinaryExpr:
+		p.expr(x.X)
+		p.write(x.Op)
+		p.expr(x.Y)
+	case *AssignExpr:
+		p.expr(x.Left)
+		p.write(x.Op)
+		p.expr(x.Right)
+	case *CondExpr:
+		p.expr(x.Test)
+		p.write("?")
+		p.expr(x.Then)
+		p.write(":")
+		p.expr(x.Else)
+	case *CallExpr:
+		p.expr(x.Callee)
+		p.write("(")
+		p.exprs(x.Args)
+		p.write(")")
+	case *NewExpr:
+		p.write("new")
+		p.expr(x.Callee)
+		p.write("(")
+		p.exprs(x.Args)
+		p.write(")")
+	case *MemberExpr:
+		p.expr(x.Object)
+		if x.Computed {
+			p.write("[")
+			p.expr(x.Prop)
+			p.write("]")
+			return
+		}
+		// 1.toString is read as a malformed number
+		if lit, ok := x.Object.(*Literal); ok && lit.Kind == Number && !strings.ContainsAny(lit.Raw, ".eExX") {
+			p.b.WriteByte(' ')
+		}
+		p.write(".")
+		p.write(x.Prop.(*Ident).Name)
+	case *SeqExpr:
+		p.exprs(x.List)
+	case *ParenExpr:
+		p.write("(")
+		p.expr(x.X)
+		p.write(")")
+	}
+}
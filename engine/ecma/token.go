@@ -0,0 +1,72 @@
+package ecma
+
+// TokenKind is the lexical class of a token.
+type TokenKind int
+
+// Token kinds
+const (
+	EOF TokenKind = iota
+	Identifier
+	Keyword
+	Punctuator
+	Number
+	String
+	RegExp
+)
+
+var kindNames = [...]string{
+	EOF:        "end of input",
+	Identifier: "identifier",
+	Keyword:    "keyword",
+	Punctuator: "punctuator",
+	Number:     "number",
+	String:     "string",
+	RegExp:     "regular expression",
+}
+
+func (k TokenKind) String() string { return kindNames[k] }
+
+// Token is a lexical token of the source.
+type Token struct {
+	Kind TokenKind
+
+	// Raw is the token as written in the source
+	Raw string
+
+	// Value is the decoded value of a string literal, or Raw otherwise
+	Value string
+
+	// Start and End are byte offsets in the source
+	Start int
+	End   int
+
+	// NewlineBefore reports whether a line terminator precedes the token,
+	// it drives the automatic semicolon insertion
+	NewlineBefore bool
+}
+
+var keywords = map[string]bool{
+	"break": true, "case": true, "catch": true, "continue": true, "debugger": true,
+	"default": true, "delete": true, "do": true, "else": true, "finally": true,
+	"for": true, "function": true, "if": true, "in": true, "instanceof": true,
+	"new": true, "return": true, "switch": true, "this": true, "throw": true,
+	"try": true, "typeof": true, "var": true, "void": true, "while": true,
+	"with": true, "null": true, "true": true, "false": true,
+	// Future reserved words
+	"class": true, "const": true, "enum": true, "export": true, "extends": true,
+	"import": true, "super": true,
+}
+
+// IsKeyword reports whether name is a reserved word.
+func IsKeyword(name string) bool { return keywords[name] }
+
+// Punctuators sorted from the longest to the shortest, the lexer picks the
+// first match.
+var punctuators = []string{
+	">>>=",
+	"===", "!==", "<<=", ">>=", ">>>", "...",
+	"<=", ">=", "==", "!=", "++", "--", "<<", ">>", "&&", "||", "=>",
+	"+=", "-=", "*=", "%=", "&=", "|=", "^=", "/=",
+	"{", "}", "(", ")", "[", "]", ".", ";", ",", "<", ">", "+", "-", "*",
+	"%", "&", "|", "^", "!", "~", "?", ":", "=", "/",
+}
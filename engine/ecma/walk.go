@@ -0,0 +1,174 @@
+package ecma
+
+// Inspect traverses the tree in depth-first order. It calls f for each node,
+// children are skipped when f returns false.
+func Inspect(n Node, f func(Node) bool) {
+	if n == nil || !f(n) {
+		return
+	}
+	for _, c := range children(n) {
+		Inspect(c, f)
+	}
+}
+
+// children returns the direct children of a node in source order.
+func children(n Node) []Node {
+	var list []Node
+	add := func(nodes ...Node) {
+		list = append(list, nodes...)
+	}
+	addExprs := func(xs []Expr) {
+		for _, x := range xs {
+			if x != nil {
+				add(x)
+			}
+		}
+	}
+	addStmts := func(ss []Stmt) {
+		for _, s := range ss {
+			add(s)
+		}
+	}
+
+	switch x := n.(type) {
+	case *Program:
+		addStmts(x.Body)
+	case *VarDecl:
+		for _, b := range x.List {
+			add(b)
+		}
+	case *VarBinding:
+		add(x.Name)
+		if x.Init != nil {
+			add(x.Init)
+		}
+	case *FuncDecl:
+		add(x.Func)
+	case *ClassDecl:
+		add(x.Class)
+	case *ExprStmt:
+		add(x.X)
+	case *BlockStmt:
+		addStmts(x.List)
+	case *IfStmt:
+		add(x.Cond, x.Then)
+		if x.Else != nil {
+			add(x.Else)
+		}
+	case *ForStmt:
+		if x.Init != nil {
+			add(x.Init)
+		}
+		if x.Cond != nil {
+			add(x.Cond)
+		}
+		if x.Update != nil {
+			add(x.Update)
+		}
+		add(x.Body)
+	case *ForInStmt:
+		add(x.Left, x.Right, x.Body)
+	case *WhileStmt:
+		add(x.Cond, x.Body)
+	case *DoWhileStmt:
+		add(x.Body, x.Cond)
+	case *ReturnStmt:
+		if x.Result != nil {
+			add(x.Result)
+		}
+	case *BranchStmt:
+		if x.Label != nil {
+			add(x.Label)
+		}
+	case *ThrowStmt:
+		add(x.X)
+	case *TryStmt:
+		add(x.Block)
+		if x.Param != nil {
+			add(x.Param)
+		}
+		if x.Handler != nil {
+			add(x.Handler)
+		}
+		if x.Finalizer != nil {
+			add(x.Finalizer)
+		}
+	case *SwitchStmt:
+		add(x.Tag)
+		for _, c := range x.Cases {
+			add(c)
+		}
+	case *CaseClause:
+		if x.Test != nil {
+			add(x.Test)
+		}
+		addStmts(x.Body)
+	case *LabeledStmt:
+		add(x.Label, x.Body)
+	case *WithStmt:
+		add(x.Object, x.Body)
+	case *ExportDecl:
+		if x.Decl != nil {
+			add(x.Decl)
+		}
+		if x.Default != nil {
+			add(x.Default)
+		}
+		for _, spec := range x.Specs {
+			add(spec)
+		}
+	case *ExportSpec:
+		add(x.Local, x.Exported)
+	case *ArrayLit:
+		addExprs(x.List)
+	case *ObjectLit:
+		for _, p := range x.Props {
+			add(p)
+		}
+	case *Property:
+		add(x.Key, x.Value)
+	case *Func:
+		if x.Name != nil {
+			add(x.Name)
+		}
+		for _, p := range x.Params {
+			add(p)
+		}
+		add(x.Body)
+	case *Class:
+		if x.Name != nil {
+			add(x.Name)
+		}
+		if x.Super != nil {
+			add(x.Super)
+		}
+		for _, m := range x.Methods {
+			add(m)
+		}
+	case *Method:
+		add(x.Key, x.Value)
+	case *UnaryExpr:
+		add(x.X)
+	case *UpdateExpr:
+		add(x.X)
+	case *BinaryExpr:
+		add(x.X, x.Y)
+	case *AssignExpr:
+		add(x.Left, x.Right)
+	case *CondExpr:
+		add(x.Test, x.Then, x.Else)
+	case *CallExpr:
+		add(x.Callee)
+		addExprs(x.Args)
+	case *NewExpr:
+		add(x.Callee)
+		addExprs(x.Args)
+	case *MemberExpr:
+		add(x.Object, x.Prop)
+	case *SeqExpr:
+		addExprs(x.List)
+	case *ParenExpr:
+		add(x.X)
+	}
+	return list
+}
@@ -1,6 +1,8 @@
 // Package engine defines everything related to script code for running a creation
 package engine
 
+import "github.com/woobleio/wooblizer/engine/doc"
+
 // StdName is the name standard for a wooble object
 const StdName string = "Woobly"
 
@@ -20,5 +22,55 @@ type Script interface {
 	IncludeHTMLCSS(srcHTML string, srcCSS string) error
 
 	// Control controles wether the object is valid or not
-	Control() []error
+	Control() []*Diagnostic
+}
+
+// OriginalSource is a text a script source comes from.
+type OriginalSource struct {
+	Name    string
+	Content string
+}
+
+// Mapping relates a range of a script source, as returned by GetSource, to
+// the original text it comes from. The offset Start+i maps to Offset+i.
+type Mapping struct {
+	Start  int
+	End    int
+	Source string
+	Offset int
+}
+
+// SourceMapper is implemented by the scripts which can locate their source in
+// the original texts, it is used to build source maps.
+type SourceMapper interface {
+	// Origins returns the original texts and the mappings of the script
+	// source
+	Origins() ([]OriginalSource, []Mapping)
+}
+
+// Sanitizer is implemented by the scripts which sanitize the markup given to
+// IncludeHTMLCSS with a policy.
+type Sanitizer interface {
+	// SetPolicy sets the policy, nil keeps all the markup
+	SetPolicy(p *doc.Policy)
+
+	// Stripped returns a warning for each element or attribute removed by
+	// the last IncludeHTMLCSS
+	Stripped() []*Diagnostic
+}
+
+// Schemer is implemented by the scripts whose parameters are typed. The
+// runtime validates the values given to init against the schema.
+type Schemer interface {
+	// GetSchema returns the schema of the typed parameters as a JavaScript
+	// object, or "" if there is none
+	GetSchema() (string, error)
+}
+
+// Styler is implemented by the scripts whose style sheet is shared by their
+// instances. The runtime creates the sheet on the first init of the creation.
+type Styler interface {
+	// GetStyle returns the style sheet given to IncludeHTMLCSS as a
+	// JavaScript string, or "" if there is none
+	GetStyle() string
 }
@@ -4,7 +4,11 @@ import "errors"
 
 // Engine errors
 var (
-	ErrNoDocInit     = errors.New("No document initiliazer found")
-	ErrNoConstructor = errors.New("No constructor")
-	ErrNoClassFound  = errors.New("No class found")
+	ErrNoDocInit          = errors.New("No document initiliazer found")
+	ErrNoConstructor      = errors.New("No constructor")
+	ErrNoClassFound       = errors.New("No class found")
+	ErrStripped           = errors.New("Markup stripped by the sanitization policy")
+	ErrUnknownPlaceholder = errors.New("Placeholder of an unknown parameter")
+	ErrInvalidParam       = errors.New("Invalid parameter")
+	ErrHelper             = errors.New("Non-standard helper replaced")
 )
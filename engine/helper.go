@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/woobleio/wooblizer/engine/ecma"
+)
+
+// Helper is a function shared by the creations, such as a Babel helper. The
+// runtime defines it once, if a creation uses it.
+type Helper struct {
+	Name   string
+	Source string // declaration of the helper
+}
+
+// Helpers are the standard Babel helpers, in the order the runtime defines
+// them. Their definitions in a creation source are stripped, the creation
+// uses the shared ones.
+var Helpers = []Helper{
+	{"_typeof", `var _typeof = typeof Symbol === "function" && typeof Symbol.iterator === "symbol" ? function (obj) { return typeof obj; } : function (obj) { return obj && typeof Symbol === "function" && obj.constructor === Symbol && obj !== Symbol.prototype ? "symbol" : typeof obj; };`},
+	{"_extends", `var _extends = Object.assign || function (target) { for (var i = 1; i < arguments.length; i++) { var source = arguments[i]; for (var key in source) { if (Object.prototype.hasOwnProperty.call(source, key)) { target[key] = source[key]; } } } return target; };`},
+	{"_slicedToArray", `var _slicedToArray = function () { function sliceIterator(arr, i) { var _arr = []; var _n = true; var _d = false; var _e = undefined; try { for (var _i = arr[Symbol.iterator](), _s; !(_n = (_s = _i.next()).done); _n = true) { _arr.push(_s.value); if (i && _arr.length === i) break; } } catch (err) { _d = true; _e = err; } finally { try { if (!_n && _i["return"]) _i["return"](); } finally { if (_d) throw _e; } } return _arr; } return function (arr, i) { if (Array.isArray(arr)) { return arr; } else if (Symbol.iterator in Object(arr)) { return sliceIterator(arr, i); } else { throw new TypeError("Invalid attempt to destructure non-iterable instance"); } }; }();`},
+	{"_createClass", `var _createClass = function () { function defineProperties(target, props) { for (var i = 0; i < props.length; i++) { var descriptor = props[i]; descriptor.enumerable = descriptor.enumerable || false; descriptor.configurable = true; if ("value" in descriptor) descriptor.writable = true; Object.defineProperty(target, descriptor.key, descriptor); } } return function (Constructor, protoProps, staticProps) { if (protoProps) defineProperties(Constructor.prototype, protoProps); if (staticProps) defineProperties(Constructor, staticProps); return Constructor; }; }();`},
+	{"_get", `var _get = function get(object, property, receiver) { if (object === null) object = Function.prototype; var desc = Object.getOwnPropertyDescriptor(object, property); if (desc === undefined) { var parent = Object.getPrototypeOf(object); if (parent === null) { return undefined; } else { return get(parent, property, receiver); } } else if ("value" in desc) { return desc.value; } else { var getter = desc.get; if (getter === undefined) { return undefined; } return getter.call(receiver); } };`},
+	{"_defineProperty", `function _defineProperty(obj, key, value) { if (key in obj) { Object.defineProperty(obj, key, { value: value, enumerable: true, configurable: true, writable: true }); } else { obj[key] = value; } return obj; }`},
+	{"_toConsumableArray", `function _toConsumableArray(arr) { if (Array.isArray(arr)) { for (var i = 0, arr2 = Array(arr.length); i < arr.length; i++) { arr2[i] = arr[i]; } return arr2; } else { return Array.from(arr); } }`},
+	{"_classCallCheck", `function _classCallCheck(instance, Constructor) { if (!(instance instanceof Constructor)) { throw new TypeError("Cannot call a class as a function"); } }`},
+	{"_possibleConstructorReturn", `function _possibleConstructorReturn(self, call) { if (!self) { throw new ReferenceError("this hasn't been initialised - super() hasn't been called"); } return call && (typeof call === "object" || typeof call === "function") ? call : self; }`},
+	{"_inherits", `function _inherits(subClass, superClass) { if (typeof superClass !== "function" && superClass !== null) { throw new TypeError("Super expression must either be null or a function, not " + typeof superClass); } subClass.prototype = Object.create(superClass && superClass.prototype, { constructor: { value: subClass, enumerable: false, writable: true, configurable: true } }); if (superClass) Object.setPrototypeOf ? Object.setPrototypeOf(subClass, superClass) : subClass.__proto__ = superClass; }`},
+}
+
+// HelperUser is implemented by the scripts which may use the Helpers.
+type HelperUser interface {
+	// UsedHelpers returns the names of the Helpers the script source uses,
+	// in the order of Helpers
+	UsedHelpers() []string
+}
+
+var (
+	helperOnce sync.Once
+	// minified Helpers by name, the definitions in the creation sources are
+	// compared once minified so the spacing and local names do not matter
+	standardHelpers map[string]string
+)
+
+// standardHelper returns the minified source of a helper, false if name is
+// not a helper.
+func standardHelper(name string) (string, bool) {
+	helperOnce.Do(func() {
+		standardHelpers = make(map[string]string, len(Helpers))
+		for _, h := range Helpers {
+			min, err := ecma.Minify(h.Source)
+			if err != nil {
+				panic("engine: helper " + h.Name + ": " + err.Error())
+			}
+			standardHelpers[h.Name] = min
+		}
+	})
+	min, ok := standardHelpers[name]
+	return min, ok
+}
+
+// helperName returns the name of a top-level helper declaration, or "".
+func helperName(st ecma.Stmt) string {
+	var name string
+	switch x := st.(type) {
+	case *ecma.FuncDecl:
+		name = x.Func.Name.Name
+	case *ecma.VarDecl:
+		if len(x.List) == 1 {
+			name = x.List[0].Name.Name
+		}
+	}
+	if _, ok := standardHelper(name); !ok {
+		return ""
+	}
+	return name
+}
+
+// helperDiagnostics warns about the helpers defined by a creation source
+// which are not the standard ones, the standard ones replace them.
+func helperDiagnostics(prog *ecma.Program) []*Diagnostic {
+	var diags []*Diagnostic
+	for _, st := range prog.Body {
+		name := helperName(st)
+		if name == "" {
+			continue
+		}
+		std, _ := standardHelper(name)
+		if min, err := ecma.Minify(prog.Source(st)); err == nil && min == std {
+			continue
+		}
+		d := NewDiagnostic(CodeHelper, ErrHelper).at(prog, st)
+		d.Severity = SeverityWarning
+		d.Message = name + " is not the standard Babel helper, the standard one replaces it"
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// helpers returns the names of the Helpers a class and the other top-level
+// statements use, in the order of Helpers.
+func (s shape) helpers(src string) []string {
+	prog, err := ecma.Parse(src)
+	if err != nil {
+		return nil
+	}
+	class, _ := s.locate(prog)
+	if class == nil {
+		return nil
+	}
+	used := make(map[string]bool)
+	var visit func(n ecma.Node)
+	visit = func(n ecma.Node) {
+		ecma.Inspect(n, func(n ecma.Node) bool {
+			switch x := n.(type) {
+			case *ecma.MemberExpr:
+				// Property names are not variables
+				if !x.Computed {
+					visit(x.Object)
+					return false
+				}
+			case *ecma.Ident:
+				used[x.Name] = true
+			}
+			return true
+		})
+	}
+	for _, st := range prog.Body {
+		if helperName(st) == "" {
+			visit(st)
+		}
+	}
+	var names []string
+	for _, h := range Helpers {
+		if used[h.Name] {
+			names = append(names, h.Name)
+		}
+	}
+	return names
+}
@@ -3,148 +3,663 @@ package engine
 import (
 	"bytes"
 	"errors"
-	"regexp"
+	"fmt"
 	"strings"
 
 	h "golang.org/x/net/html"
 
+	"github.com/woobleio/wooblizer/engine/css"
 	"github.com/woobleio/wooblizer/engine/doc"
+	"github.com/woobleio/wooblizer/engine/ecma"
 )
 
 // JS Object
+//
+//	var Woobly = function Woobly(params) {
+//		this.document = document.body.shadowRoot;
+//	};
 type JS struct {
+	creation
+}
+
+// creation is the state and the methods shared by the JS engines, they
+// differ by the shape of their class.
+type creation struct {
 	Name   string
 	Src    string
 	Params []JSParam
+
+	// Strategy is how IncludeHTMLCSS builds the DOM
+	Strategy Strategy
+
+	shape    shape
+	policy   *doc.Policy
+	stripped []*Diagnostic
+
+	// style is the style sheet given to the last IncludeHTMLCSS
+	style string
+
+	// binds are the parameters bound by the last IncludeHTMLCSS
+	binds []binding
+
+	// orig is the source given to the constructor of the creation
+	orig string
 }
 
-// JSParam is a object parameter
+// JSParam is a object parameter. An untyped parameter has a default Value
+// written in JavaScript, it is pasted as is in the library. A typed parameter
+// has a Go Default, serialized into a JavaScript literal, and the values
+// given to init are validated against its type and rules.
 type JSParam struct {
 	Field string
 	Value string
+
+	Type     ParamType
+	Default  interface{} // nil if the parameter has no default
+	Required bool        // init fails without a value of the parameter
+	Rules    *ParamRules // nil if the values are not constrained
 }
 
 const docVar string = "this.document"
 
+// Strategy is how the prologue generated by IncludeHTMLCSS builds the DOM of
+// a creation. All the strategies build the same DOM.
+type Strategy int
+
+const (
+	// Auto builds node by node, or with a template from TemplateThreshold
+	// nodes
+	Auto Strategy = iota
+	// NodeByNode creates and appends every node
+	NodeByNode
+	// Template parses the markup once into a <template> shared by the
+	// instances, and clones its content
+	Template
+)
+
+// TemplateThreshold is the number of nodes from which Auto uses a template.
+const TemplateThreshold = 64
+
+// Variables of the generated shadow DOM prologue, names starting with two
+// underscores are reserved for the nodes created by the prologue
 const (
-	docRegex         string = `this.document[ ]?=[ ]?document.body.shadowRoot`
-	constructorRegex string = `.*function Woobly\(`
-	classRegex       string = `var Woobly[ ]?=`
+	targetVar string = "_t_"  // Element hosting the creation
+	sRootVar  string = "_sr_" // Shadow root element
 )
 
 // NewJS initializes a native JS ES2015 creation
 // name: creation's name
 // src: source code
 // params: creation's parameters
-func NewJS(name string, src string, params []JSParam) (*JS, []error) {
-	js := &JS{
+func NewJS(name string, src string, params []JSParam) (*JS, []*Diagnostic) {
+	js := &JS{creation{
 		Name:   name,
 		Src:    src,
 		Params: params,
-	}
+		shape:  jsShape,
+		orig:   src,
+	}}
 
-	errs := js.Control()
-
-	rmVar := regexp.MustCompile(classRegex)
-	src = rmVar.ReplaceAllString(src, "")
-	src = strings.TrimRight(src, ";")
+	return js, js.Control()
+}
 
-	js.Src = src
+// DecodeJSParams decodes creation parameters given as JSParam, or as maps
+// such as JSON objects. A map has a "field" and a "value" key, or a "type"
+// key and the keys of a typed parameter: "default", "required", "min",
+// "max", "pattern", "enum" and "items".
+func DecodeJSParams(params []interface{}) ([]interface{}, error) {
+	decoded := make([]interface{}, len(params))
+	for i, p := range params {
+		switch v := p.(type) {
+		case JSParam:
+			decoded[i] = v
+		case *JSParam:
+			decoded[i] = *v
+		case map[string]interface{}:
+			p, err := decodeJSParam(v)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %d: %s", i, err)
+			}
+			decoded[i] = p
+		case map[string]string:
+			decoded[i] = JSParam{Field: v["field"], Value: v["value"]}
+		default:
+			return nil, fmt.Errorf("parameter %d: unsupported type %T", i, p)
+		}
+	}
+	return decoded, nil
+}
 
-	return js, errs
+// NewJSScript is NewJS taking decoded parameters, it is the factory of the JS
+// engine.
+func NewJSScript(name string, src string, params []interface{}) (Script, []*Diagnostic) {
+	return NewJS(name, src, toJSParams(params))
 }
 
 // GetName returns obj name
-func (js *JS) GetName() string { return js.Name }
+func (c *creation) GetName() string { return c.Name }
+
+// GetSource returns obj code source, that is the class expression or
+// declaration without the Babel helpers
+func (c *creation) GetSource() string { return c.shape.source(c.Src) }
 
-// GetSource returns obj code source
-func (js *JS) GetSource() string { return js.Src }
+// UsedHelpers returns the Babel helpers used by the source, their
+// definitions are not part of GetSource.
+func (c *creation) UsedHelpers() []string { return c.shape.helpers(c.Src) }
 
 // GetParams returns obj parameters
-func (js *JS) GetParams() []interface{} {
-	var interf = make([]interface{}, len(js.Params))
-	for i, p := range js.Params {
+func (c *creation) GetParams() []interface{} { return fromJSParams(c.Params) }
+
+// Origins maps the source returned by GetSource to the source given to the
+// constructor, and to the prologue generated by IncludeHTMLCSS. If the source
+// was changed otherwise, it is its own original.
+func (c *creation) Origins() ([]OriginalSource, []Mapping) {
+	return c.shape.origins(c.Name, c.Src, c.orig)
+}
+
+// IncludeHTMLCSS includes HTML and CSS in the object. It replaces the document
+// initialization of the constructor with a prologue building the shadow DOM,
+// and adds the target parameter to the constructor. Calling it again replaces
+// the prologue previously generated. The CSS is compiled, a syntax error is
+// returned as a *Diagnostic. So is the first placeholder of a parameter the
+// creation does not have, the markup is included and Control reports them
+// all.
+func (c *creation) IncludeHTMLCSS(srcHTML string, srcCSS string) error {
+	style, err := compileStyle(c.Name, srcCSS)
+	if err != nil {
+		return err
+	}
+	html, err := doc.NewHTML(srcHTML, doc.WithPolicy(c.policy))
+	if err != nil {
+		return errors.New("DOM error : " + err.Error())
+	}
+	frag := html.Fragment()
+	src, err := c.shape.includeHTMLCSS(c.Src, frag, style, c.policy, c.Strategy)
+	if err != nil {
+		return err
+	}
+	c.Src = src
+	c.stripped = strippedDiagnostics(c.Name, html.Stripped())
+	c.style = style
+	c.binds = bindings(frag, srcHTML, srcCSS)
+	if diags := unknownBindings(c.Name, c.binds, c.Params); len(diags) > 0 {
+		return diags[0]
+	}
+	return nil
+}
+
+// GetStyle returns the style sheet given to IncludeHTMLCSS, compiled, as a
+// JavaScript string, or "" if there is none.
+func (c *creation) GetStyle() string { return styleString(c.style) }
+
+// SetPolicy sets the policy sanitizing the markup given to IncludeHTMLCSS.
+func (c *creation) SetPolicy(p *doc.Policy) { c.policy = p }
+
+// Stripped returns the markup removed by the last IncludeHTMLCSS.
+func (c *creation) Stripped() []*Diagnostic { return c.stripped }
+
+// GetSchema returns the schema of the typed parameters validating the values
+// given to init, as a JavaScript object, or "" if there is none.
+func (c *creation) GetSchema() (string, error) { return paramsSchema(c.Params) }
+
+// Control checks if the class is valid. The source must declare the top-level
+// Woobly class of the engine, with a constructor which initializes the
+// document with the shadow root. The parameters must be valid, and the
+// parameters bound by IncludeHTMLCSS must be parameters of the creation.
+func (c *creation) Control() []*Diagnostic {
+	diags := c.shape.control(c.Name, c.Src)
+	diags = append(diags, paramDiagnostics(c.Name, c.Params)...)
+	return append(diags, unknownBindings(c.Name, c.binds, c.Params)...)
+}
+
+func toJSParams(params []interface{}) []JSParam {
+	jsParams := make([]JSParam, len(params))
+	for i, p := range params {
+		jsParams[i] = p.(JSParam)
+	}
+	return jsParams
+}
+
+func fromJSParams(params []JSParam) []interface{} {
+	var interf = make([]interface{}, len(params))
+	for i, p := range params {
 		interf[i] = p
 	}
 	return interf
 }
 
-// IncludeHTMLCSS includes HTML and CSS in the object
-func (js *JS) IncludeHTMLCSS(srcHTML string, srcCSS string) error {
-	// Fixes net/html new line reading as text node... It breaks the generated script
-	doc, err := doc.NewHTML(sanitize(srcHTML))
+// shape is the way a creation declares its class, the engines share the
+// controls and the shadow DOM injection of the constructor.
+type shape struct {
+	// locate returns the class node, the source of the creation, and its
+	// constructor. Both are nil when not found.
+	locate func(prog *ecma.Program) (class ecma.Node, constructor *ecma.Func)
+
+	// Messages of the no-class and no-constructor diagnostics
+	noClass       string
+	noConstructor string
+}
+
+var jsShape = shape{
+	locateJS,
+	"no top-level var " + StdName + " declaration found",
+	StdName + " is not bound to a function " + StdName + " constructor",
+}
+
+func locateJS(prog *ecma.Program) (ecma.Node, *ecma.Func) {
+	class := findClass(prog)
+	if class == nil {
+		return nil, nil
+	}
+	return class.Init, findConstructor(class)
+}
+
+// piece is a range of a class source, placed at an offset of the source of
+// the creation.
+type piece struct {
+	at    int
+	start int
+	end   int
+}
+
+// assemble returns the source of the creation and the pieces of src it is
+// made of. It is the class alone, or with the other top-level statements an
+// immediately invoked function returning the class. The Babel helpers are
+// left out, the runtime shares them.
+func (s shape) assemble(prog *ecma.Program, class ecma.Node) (string, []piece) {
+	var kept []ecma.Stmt
+	alone := true
+	for _, st := range prog.Body {
+		if _, empty := st.(*ecma.EmptyStmt); empty || helperName(st) != "" {
+			continue
+		}
+		kept = append(kept, st)
+		alone = alone && st.Pos() <= class.Pos() && class.End() <= st.End()
+	}
+	src := prog.Source(prog)
+	if alone {
+		return src[class.Pos():class.End()], []piece{{0, class.Pos(), class.End()}}
+	}
+
+	var b strings.Builder
+	var pieces []piece
+	b.WriteString("function () {\n")
+	for _, st := range kept {
+		pieces = append(pieces, piece{b.Len(), st.Pos(), st.End()})
+		b.WriteString(src[st.Pos():st.End()])
+		b.WriteString("\n")
+	}
+	b.WriteString("return " + StdName + ";\n}()")
+	return b.String(), pieces
+}
+
+// source returns the source of the creation, see assemble.
+func (s shape) source(src string) string {
+	prog, err := ecma.Parse(src)
 	if err != nil {
-		return errors.New("DOM error : " + err.Error())
+		return src
 	}
+	if class, _ := s.locate(prog); class != nil {
+		out, _ := s.assemble(prog, class)
+		return out
+	}
+	return src
+}
 
-	initDocRegex := regexp.MustCompile(docRegex)
-	if !initDocRegex.MatchString(js.Src) {
-		return errors.New("No document initilization found. this.document = document is required in the object consctructor")
+// origins maps the source of the creation to the original source and to the
+// prologue.
+func (s shape) origins(name string, src string, orig string) ([]OriginalSource, []Mapping) {
+	file := name + ".js"
+	prog, err := ecma.Parse(src)
+	if err != nil {
+		return []OriginalSource{{file, src}}, []Mapping{{0, len(src), file, 0}}
+	}
+	class, constructor := s.locate(prog)
+	if class == nil {
+		return []OriginalSource{{file, src}}, []Mapping{{0, len(src), file, 0}}
+	}
+	_, pieces := s.assemble(prog, class)
+	identity := placeMappings(pieces, []Mapping{{0, len(src), file, 0}})
+	if orig == "" || orig == src || constructor == nil {
+		return []OriginalSource{{file, src}}, identity
 	}
 
-	sRootVar := "_sr_" // Shadow root element
-	jsw := newJsWriter(sRootVar)
-	constructorRegex := regexp.MustCompile(constructorRegex)
-	constructorIdx := constructorRegex.FindIndex([]byte(js.Src))
-	srcToBytes := []byte(js.Src)
-	index := constructorIdx[1]
+	origProg, err := ecma.Parse(orig)
+	if err != nil {
+		return []OriginalSource{{file, src}}, identity
+	}
+	_, origConstructor := s.locate(origProg)
+	if origConstructor == nil || origConstructor.Lparen != constructor.Lparen {
+		return []OriginalSource{{file, src}}, identity
+	}
+	start, stop, _ := findPrologue(constructor)
+	origStart, origStop, ok := findPrologue(origConstructor)
+
+	// The original source with the target parameter inserted and the
+	// prologue replaced
+	lparen := constructor.Lparen + 1
+	param := lparen + start - origStart
+	if !ok || param < lparen || src[:lparen] != orig[:lparen] ||
+		src[param:start] != orig[lparen:origStart] || src[stop:] != orig[origStop:] {
+		return []OriginalSource{{file, src}}, identity
+	}
 
-	coma := ","
-	if string(srcToBytes[index:index+1]) == ")" {
-		coma = ""
+	prologue := name + ".prologue.js"
+	return []OriginalSource{{file, orig}, {prologue, src[start:stop]}}, placeMappings(pieces, []Mapping{
+		{0, lparen, file, 0},
+		{param, start, file, lparen},
+		{start, stop, prologue, 0},
+		{stop, len(src), file, origStop},
+	})
+}
+
+// placeMappings converts mappings of a class source into mappings of the
+// source of the creation made of pieces.
+func placeMappings(pieces []piece, mappings []Mapping) []Mapping {
+	var placed []Mapping
+	for _, p := range pieces {
+		for _, m := range mappings {
+			start, end := m.Start, m.End
+			if start < p.start {
+				start = p.start
+			}
+			if end > p.end {
+				end = p.end
+			}
+			if start >= end {
+				continue
+			}
+			placed = append(placed, Mapping{p.at + start - p.start, p.at + end - p.start, m.Source, m.Offset + start - m.Start})
+		}
 	}
-	// Insert target parameter in the object constructor
-	js.Src = string(append(srcToBytes[:index], append([]byte("_t_"+coma), srcToBytes[index:]...)...))
+	return placed
+}
 
-	jsw.affectVar(sRootVar, "_t_.attachShadow({mode:'open'})")
-	if srcHTML != "" {
-		doc.ReadAndExecute(jsw.buildNode, 0)
+// includeHTMLCSS returns the source with the prologue of the constructor
+// replaced by the statements building the shadow DOM of frag, the markup
+// sanitized with policy, and the target parameter added to the constructor.
+func (s shape) includeHTMLCSS(src string, frag *h.Node, srcCSS string, policy *doc.Policy, strategy Strategy) (string, error) {
+	prog, err := ecma.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	class, constructor := s.locate(prog)
+	if class == nil {
+		return "", ErrNoClassFound
+	}
+	if constructor == nil {
+		return "", ErrNoConstructor
+	}
+	start, end, ok := findPrologue(constructor)
+	if !ok {
+		return "", ErrNoDocInit
+	}
+
+	bound := hasPlaceholders(frag)
+	toks, _ := css.Tokenize(srcCSS)
+	props := styleBindings(toks)
+
+	jsw := newJsWriter(sRootVar)
+	jsw.policy = policy
+	jsw.affectVar(sRootVar, targetVar+".attachShadow({mode:'open'})")
+	if bound || len(props) > 0 {
+		jsw.params()
+	}
+	if strategy == Template || strategy == Auto && countNodes(frag) >= TemplateThreshold {
+		jsw.cloneTemplate(frag, bound)
+	} else {
+		for c := frag.FirstChild; c != nil; c = c.NextSibling {
+			jsw.buildTree(c, 0)
+		}
 	}
+	jsw.bindStyle(targetVar, props)
 	jsw.affectAttr("this", "document", sRootVar)
 
 	if srcCSS != "" {
-		styleVar := "__s"
-		jsw.affectVar(styleVar, "")
-		jsw.createElement("style")
-		jsw.affectAttr(styleVar, "innerHTML", "'"+sanitize(sanitizeString(srcCSS))+"'")
+		jsw.adoptStyleSheet()
+	}
+
+	out := src[:start] + jsw.bf.String() + src[end:]
+
+	// Insert target parameter in the object constructor
+	if len(constructor.Params) == 0 || constructor.Params[0].Name != targetVar {
+		at := constructor.Lparen + 1
+		param := targetVar
+		if len(constructor.Params) > 0 {
+			param += ", "
+		}
+		out = out[:at] + param + out[at:]
+	}
+
+	return out, nil
+}
+
+// control checks the class shape, and that its constructor initializes the
+// document.
+func (s shape) control(name string, src string) []*Diagnostic {
+	diags := make([]*Diagnostic, 0)
+	named := func(d *Diagnostic) *Diagnostic {
+		d.Name = name
+		return d
+	}
+
+	prog, err := ecma.Parse(src)
+	if err != nil {
+		return append(diags, named(syntaxDiagnostic(err.(*ecma.SyntaxError))))
+	}
+	for _, d := range helperDiagnostics(prog) {
+		diags = append(diags, named(d))
+	}
 
-		jsw.appendChild(docVar, styleVar)
+	class, constructor := s.locate(prog)
+	if class == nil {
+		d := NewDiagnostic(CodeNoClass, ErrNoClassFound).at(prog, prog)
+		d.Message = s.noClass
+		return append(diags, named(d))
+	}
+	if constructor == nil {
+		d := NewDiagnostic(CodeNoConstructor, ErrNoConstructor).at(prog, class)
+		d.Message = s.noConstructor
+		return append(diags, named(d))
+	}
+	if _, _, ok := findPrologue(constructor); !ok {
+		d := NewDiagnostic(CodeNoDocInit, ErrNoDocInit).at(prog, constructor.Body)
+		d.Message = "the constructor must initialize this.document with document.body.shadowRoot"
+		insert := position(prog, constructor.Body.Pos()+1)
+		d.Fix = &Fix{
+			Message: "Initialize the document",
+			Range:   Range{insert, insert},
+			NewText: docVar + " = document.body.shadowRoot;",
+		}
+		diags = append(diags, named(d))
 	}
 
-	js.Src = string(initDocRegex.ReplaceAll([]byte(js.Src), jsw.bf.Bytes()))
+	return diags
+}
 
+// findClass returns the top-level binding of the Woobly variable.
+func findClass(prog *ecma.Program) *ecma.VarBinding {
+	for _, st := range prog.Body {
+		decl, ok := st.(*ecma.VarDecl)
+		if !ok {
+			continue
+		}
+		for _, b := range decl.List {
+			if b.Name.Name == StdName && b.Init != nil {
+				return b
+			}
+		}
+	}
 	return nil
 }
 
-// Control checks if the class is valid
-func (js *JS) Control() []error {
-	docR := regexp.MustCompile(docRegex)
-	constR := regexp.MustCompile(constructorRegex)
-	classR := regexp.MustCompile(classRegex + "[ ]?function.*")
+// findConstructor returns the Woobly constructor function, it is either the
+// bound function itself or, as Babel outputs it, a function declared in an
+// immediately invoked function.
+//
+//	var Woobly = function Woobly(params) {...};
+//	var Woobly = function () { function Woobly(params) {...} return Woobly; }();
+func findConstructor(class *ecma.VarBinding) *ecma.Func {
+	switch x := ecma.Unparen(class.Init).(type) {
+	case *ecma.Func:
+		if x.Name != nil && x.Name.Name == StdName {
+			return x
+		}
+	case *ecma.CallExpr:
+		fn, ok := ecma.Unparen(x.Callee).(*ecma.Func)
+		if !ok {
+			return nil
+		}
+		for _, st := range fn.Body.List {
+			if decl, ok := st.(*ecma.FuncDecl); ok && decl.Func.Name.Name == StdName {
+				return decl.Func
+			}
+		}
+	}
+	return nil
+}
 
-	errs := make([]error, 0)
+// findDocInit returns the statement of the constructor body which assigns the
+// shadow root to this.document.
+func findDocInit(constructor *ecma.Func) *ecma.ExprStmt {
+	for _, st := range constructor.Body.List {
+		es, ok := st.(*ecma.ExprStmt)
+		if !ok {
+			continue
+		}
+		as, ok := es.X.(*ecma.AssignExpr)
+		if !ok || as.Op != "=" {
+			continue
+		}
+		if isMemberPath(as.Left, "this", "document") && isMemberPath(as.Right, "document", "body", "shadowRoot") {
+			return es
+		}
+	}
+	return nil
+}
 
-	if !classR.MatchString(js.GetSource()) {
-		errs = append(errs, ErrNoClassFound)
+// findPrologue returns the range of the constructor statements building the
+// shadow DOM: the prologue generated by IncludeHTMLCSS if any, the document
+// initialization otherwise. The generated prologue starts with the shadow
+// root declaration and ends with its assignment to this.document, followed
+// by the adoption of the style sheet if there is one.
+func findPrologue(constructor *ecma.Func) (start int, end int, ok bool) {
+	list := constructor.Body.List
+	for i, st := range list {
+		decl, isVar := st.(*ecma.VarDecl)
+		if !isVar || decl.List[0].Name.Name != sRootVar {
+			continue
+		}
+		end = st.End()
+		for j, next := range list[i+1:] {
+			if !isShadowDocInit(next) {
+				continue
+			}
+			end = next.End()
+			if rest := list[i+j+2:]; len(rest) >= 2 && isSheetAdoption(rest[0], rest[1]) {
+				end = rest[1].End()
+			}
+			break
+		}
+		return st.Pos(), end, true
 	}
-	if !constR.MatchString(js.GetSource()) {
-		errs = append(errs, ErrNoConstructor)
+	if st := findDocInit(constructor); st != nil {
+		return st.Pos(), st.End(), true
 	}
-	if !docR.MatchString(js.GetSource()) {
-		errs = append(errs, ErrNoDocInit)
+	return 0, 0, false
+}
+
+// isShadowDocInit reports whether st is the generated this.document = _sr_.
+func isShadowDocInit(st ecma.Stmt) bool {
+	es, ok := st.(*ecma.ExprStmt)
+	if !ok {
+		return false
 	}
+	as, ok := es.X.(*ecma.AssignExpr)
+	return ok && as.Op == "=" && isMemberPath(as.Left, "this", "document") && isMemberPath(as.Right, sRootVar)
+}
 
-	return errs
+// isSheetAdoption reports whether decl and cond are the generated adoption
+// of the style sheet, see jsWriter.adoptStyleSheet.
+func isSheetAdoption(decl ecma.Stmt, cond ecma.Stmt) bool {
+	d, ok := decl.(*ecma.VarDecl)
+	if !ok || len(d.List) != 1 || d.List[0].Name.Name != "__ss" || d.List[0].Init == nil || !isMemberPath(d.List[0].Init, "this", "constructor", "__ss") {
+		return false
+	}
+	_, ok = cond.(*ecma.IfStmt)
+	return ok
 }
 
-func sanitize(src string) string {
-	rpcer := strings.NewReplacer("\n", "", "\t", "", "\r", "")
-	return rpcer.Replace(src)
+// isMemberPath reports whether x is a dotted access such as this.document.
+func isMemberPath(x ecma.Expr, path ...string) bool {
+	x = ecma.Unparen(x)
+	last := len(path) - 1
+	if last == 0 {
+		switch root := x.(type) {
+		case *ecma.Ident:
+			return root.Name == path[0]
+		case *ecma.ThisExpr:
+			return path[0] == "this"
+		}
+		return false
+	}
+	m, ok := x.(*ecma.MemberExpr)
+	if !ok || m.Computed || m.Prop.(*ecma.Ident).Name != path[last] {
+		return false
+	}
+	return isMemberPath(m.Object, path[:last]...)
 }
 
-func sanitizeString(src string) string {
-	rpcer := strings.NewReplacer("'", "\\'")
-	return rpcer.Replace(src)
+// jsString encodes a string as a single quoted JavaScript literal, which
+// can be embedded in an HTML script element. Quotes, backslashes, control
+// characters and line terminators (U+2028 and U+2029 included) are escaped,
+// and so are the < of </script> and <!--. Invalid UTF-8 bytes are replaced
+// with U+FFFD.
+func jsString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('\'')
+	for i, r := range s {
+		switch r {
+		case '\'', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\u2028':
+			b.WriteString(`\u2028`)
+		case '\u2029':
+			b.WriteString(`\u2029`)
+		case '<':
+			if strings.HasPrefix(s[i+1:], "/") || strings.HasPrefix(s[i+1:], "!") {
+				b.WriteString(`\x3C`)
+			} else {
+				b.WriteByte('<')
+			}
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\x%02X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// namespaces are the URIs of the element and attribute namespaces of
+// golang.org/x/net/html
+var namespaces = map[string]string{
+	"svg":   "http://www.w3.org/2000/svg",
+	"math":  "http://www.w3.org/1998/Math/MathML",
+	"xlink": "http://www.w3.org/1999/xlink",
+	"xml":   "http://www.w3.org/XML/1998/namespace",
+	"xmlns": "http://www.w3.org/2000/xmlns/",
 }
 
 // jsWriter is a syntactic sugar for writing JS using a buffer
@@ -159,6 +674,9 @@ type jsWriter struct {
 
 	// All created variables
 	vars []string
+
+	// policy sanitizing the markup, nil if there is none
+	policy *doc.Policy
 }
 
 func newJsWriter(baseVar string) *jsWriter {
@@ -169,6 +687,7 @@ func newJsWriter(baseVar string) *jsWriter {
 		baseVar,
 		baseVar,
 		vars,
+		nil,
 	}
 }
 
@@ -225,11 +744,17 @@ func (jsw *jsWriter) buildNode(node *h.Node, pIndex int) int {
 	jsw.affectVar("", "")
 	switch node.Type {
 	case h.ElementNode:
-		jsw.createElement(node.Data)
+		if uri, ok := namespaces[node.Namespace]; ok {
+			jsw.createElementNS(uri, node.Data)
+		} else {
+			jsw.createElement(node.Data)
+		}
 	case h.TextNode:
 		jsw.createTextNode(node.Data)
+	case h.CommentNode:
+		jsw.createComment(node.Data)
 	}
-	jsw.setAttributes(node.Attr)
+	jsw.setAttributes(node.Attr, node.Namespace != "")
 
 	jsVar := jsw.vars[pIndex]
 	if jsVar != jsw.baseVar {
@@ -240,13 +765,115 @@ func (jsw *jsWriter) buildNode(node *h.Node, pIndex int) int {
 	return len(jsw.vars) - 1
 }
 
+// buildTree builds a node and its descendants
+func (jsw *jsWriter) buildTree(node *h.Node, pIndex int) {
+	i := jsw.buildNode(node, pIndex)
+	for c := node.FirstChild; c != nil; c = c.NextSibling {
+		jsw.buildTree(c, i)
+	}
+}
+
+// cloneTemplate serializes the children of frag into a template cached by
+// the constructor, and appends a clone of its content to the base variable.
+// The markup is parsed once, adjacent texts are parsed as one text node. If
+// bound, the placeholders of the clone are replaced before it is appended.
+// | Example |
+// => var __tp = this.constructor.__tp;
+// if (!__tp) { __tp = this.constructor.__tp = document.createElement('template'); __tp.innerHTML = '<p>hello</p>'; }
+// _sr_.appendChild(__tp.content.cloneNode(true));
+func (jsw *jsWriter) cloneTemplate(frag *h.Node, bound bool) {
+	var markup bytes.Buffer
+	for c := frag.FirstChild; c != nil; c = c.NextSibling {
+		h.Render(&markup, c)
+	}
+	if markup.Len() == 0 {
+		return
+	}
+	tplVar := "__tp"
+	jsw.affectVar(tplVar, "this.constructor."+tplVar)
+	jsw.bf.WriteString("if (!" + tplVar + ") { " + tplVar + " = this.constructor." + tplVar + " = ")
+	jsw.createElement("template")
+	jsw.bf.WriteRune(' ')
+	jsw.affectAttr(tplVar, "innerHTML", jsString(markup.String()))
+	jsw.bf.WriteString(" }")
+	if !bound {
+		jsw.appendChild(jsw.baseVar, tplVar+".content.cloneNode(true)")
+		return
+	}
+	cloneVar := "__f"
+	jsw.affectVar(cloneVar, tplVar+".content.cloneNode(true)")
+	jsw.bindTemplate(cloneVar)
+	jsw.appendChild(jsw.baseVar, cloneVar)
+}
+
+// adoptStyleSheet adopts the style sheet created by the runtime on the first
+// init of the creation, or appends a style element with its source if
+// constructable style sheets are not supported.
+// | Example |
+// => var __ss = this.constructor.__ss;
+// if (__ss && 'adoptedStyleSheets' in _sr_) { _sr_.adoptedStyleSheets = [__ss]; }
+// else if (this.constructor.__css) { var __s = document.createElement('style'); __s.innerHTML = this.constructor.__css; this.document.appendChild(__s); }
+func (jsw *jsWriter) adoptStyleSheet() {
+	sheetVar, styleVar := "__ss", "__s"
+	jsw.affectVar(sheetVar, "this.constructor."+sheetVar)
+	jsw.bf.WriteString("if (" + sheetVar + " && 'adoptedStyleSheets' in " + jsw.baseVar + ") { ")
+	jsw.affectAttr(jsw.baseVar, "adoptedStyleSheets", "["+sheetVar+"]")
+	jsw.bf.WriteString(" } else if (this.constructor.__css) { ")
+	jsw.affectVar(styleVar, "")
+	jsw.createElement("style")
+	jsw.affectAttr(styleVar, "innerHTML", "this.constructor.__css")
+	jsw.appendChild(docVar, styleVar)
+	jsw.bf.WriteString(" }")
+}
+
+// compileStyle compiles the CSS of a creation, see css.Compile.
+func compileStyle(name string, srcCSS string) (string, error) {
+	style, err := css.Compile(srcCSS)
+	if err != nil {
+		d := cssDiagnostic(err.(*css.SyntaxError))
+		d.Name = name
+		return "", d
+	}
+	return style, nil
+}
+
+// styleString encodes a style sheet for the runtime, "" if there is none
+func styleString(css string) string {
+	if css == "" {
+		return ""
+	}
+	return jsString(css)
+}
+
+// countNodes returns the number of descendants of n
+func countNodes(n *h.Node) int {
+	count := 0
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		count += 1 + countNodes(c)
+	}
+	return count
+}
+
 // | Example |
 // el: div
 // => document.createElement('div');
 func (jsw *jsWriter) createElement(el string) {
-	jsw.bf.WriteString("document.createElement('")
-	jsw.bf.WriteString(el)
-	jsw.bf.WriteString("')")
+	jsw.bf.WriteString("document.createElement(")
+	jsw.bf.WriteString(jsString(el))
+	jsw.bf.WriteString(")")
+	jsw.endExpr()
+}
+
+// | Example |
+// uri: 'http://www.w3.org/2000/svg'
+// el: svg
+// => document.createElementNS('http://www.w3.org/2000/svg', 'svg');
+func (jsw *jsWriter) createElementNS(uri string, el string) {
+	jsw.bf.WriteString("document.createElementNS(")
+	jsw.bf.WriteString(jsString(uri))
+	jsw.bf.WriteString(", ")
+	jsw.bf.WriteString(jsString(el))
+	jsw.bf.WriteString(")")
 	jsw.endExpr()
 }
 
@@ -254,9 +881,19 @@ func (jsw *jsWriter) createElement(el string) {
 // text: 'hello world'
 // => document.createTextNode('hello world');
 func (jsw *jsWriter) createTextNode(text string) {
-	jsw.bf.WriteString("document.createTextNode('")
-	jsw.bf.WriteString(text)
-	jsw.bf.WriteString("')")
+	jsw.bf.WriteString("document.createTextNode(")
+	jsw.bf.WriteString(bindString(text))
+	jsw.bf.WriteString(")")
+	jsw.endExpr()
+}
+
+// | Example |
+// text: 'note'
+// => document.createComment('note');
+func (jsw *jsWriter) createComment(text string) {
+	jsw.bf.WriteString("document.createComment(")
+	jsw.bf.WriteString(jsString(text))
+	jsw.bf.WriteString(")")
 	jsw.endExpr()
 }
 
@@ -264,38 +901,56 @@ func (jsw *jsWriter) endExpr() {
 	jsw.bf.WriteRune(';')
 }
 
-// genUniqueVar generates a deterministic unique variable name within the jsWriter instance
+// genUniqueVar generates a deterministic unique variable name within the jsWriter instance,
+// the names of the bindings are skipped
 func (jsw *jsWriter) genUniqueVar() {
 	baseNames := [26]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z"}
-	tLength := len(jsw.vars)
-	bLength := len(baseNames)
-	if tLength >= bLength {
-		mod := tLength % bLength
-		time := tLength / bLength
-
-		jsw.vars = append(jsw.vars, jsw.vars[(time-1)*bLength]+baseNames[mod])
-	} else {
-		jsw.vars = append(jsw.vars, baseNames[tLength])
+	for {
+		tLength := len(jsw.vars)
+		bLength := len(baseNames)
+		if tLength >= bLength {
+			mod := tLength % bLength
+			time := tLength / bLength
+
+			jsw.vars = append(jsw.vars, jsw.vars[(time-1)*bLength]+baseNames[mod])
+		} else {
+			jsw.vars = append(jsw.vars, baseNames[tLength])
+		}
+		jsw.cVar = "__" + jsw.vars[len(jsw.vars)-1]
+		if jsw.cVar != paramsVar && jsw.cVar != valueVar && jsw.cVar != urlVar {
+			return
+		}
 	}
-	jsw.cVar = "__" + jsw.vars[len(jsw.vars)-1]
 }
 
-// setAttributes adds attributes to nodes with the JavaScript prototype "setAttribute"
+// setAttributes adds attributes to nodes with the JavaScript prototype "setAttribute",
+// or "setAttributeNS" for the namespaced attributes of SVG and MathML elements
 // ex : divNode.setAttribute('class', 'foobar');
-func (jsw *jsWriter) setAttributes(attrs []h.Attribute) {
+// ex : useNode.setAttributeNS('http://www.w3.org/1999/xlink', 'xlink:href', '#icon');
+func (jsw *jsWriter) setAttributes(attrs []h.Attribute, foreign bool) {
 	var attrKey string
 	for _, attr := range attrs {
-		if len(attr.Namespace) > 0 {
-			attrKey = attr.Namespace + ":" + attr.Key
+		ns := attr.Namespace
+		if len(ns) > 0 {
+			attrKey = ns + ":" + attr.Key
 		} else {
 			attrKey = attr.Key
 		}
+		if foreign && ns == "" && attr.Key == "xmlns" {
+			ns = "xmlns"
+		}
 		jsw.bf.WriteString(jsw.cVar)
-		jsw.bf.WriteString(".setAttribute('")
-		jsw.bf.WriteString(attrKey)
-		jsw.bf.WriteString("', '")
-		jsw.bf.WriteString(attr.Val)
-		jsw.bf.WriteString("')")
+		if uri, ok := namespaces[ns]; ok && foreign {
+			jsw.bf.WriteString(".setAttributeNS(")
+			jsw.bf.WriteString(jsString(uri))
+			jsw.bf.WriteString(", ")
+		} else {
+			jsw.bf.WriteString(".setAttribute(")
+		}
+		jsw.bf.WriteString(jsString(attrKey))
+		jsw.bf.WriteString(", ")
+		jsw.bf.WriteString(jsw.bindAttr(attrKey, attr.Val))
+		jsw.bf.WriteString(")")
 		jsw.endExpr()
 	}
 }
@@ -1,10 +1,19 @@
 package engine_test
 
 import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/woobleio/wooblizer/engine"
+	"github.com/woobleio/wooblizer/engine/doc"
+	"github.com/woobleio/wooblizer/engine/ecma"
+	xhtml "golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 func TestIncludeHtml(t *testing.T) {
@@ -18,7 +27,7 @@ func TestIncludeHtml(t *testing.T) {
 
 	s.IncludeHTMLCSS("<div class='heelo' id='hello'>test</div>", "div { color: red }")
 
-	expected := `var _sr_ = _t_.attachShadow({mode:'open'});var __b = document.createElement('div');__b.setAttribute('class', 'heelo');__b.setAttribute('id', 'hello');_sr_.appendChild(__b);var __c = document.createTextNode('test');__b.appendChild(__c);this.document = _sr_;var __s = document.createElement('style');__s.innerHTML = 'div { color: red }';this.document.appendChild(__s);`
+	expected := `var _sr_ = _t_.attachShadow({mode:'open'});var __b = document.createElement('div');__b.setAttribute('class', 'heelo');__b.setAttribute('id', 'hello');_sr_.appendChild(__b);var __c = document.createTextNode('test');__b.appendChild(__c);this.document = _sr_;var __ss = this.constructor.__ss;if (__ss && 'adoptedStyleSheets' in _sr_) { _sr_.adoptedStyleSheets = [__ss]; } else if (this.constructor.__css) { var __s = document.createElement('style');__s.innerHTML = this.constructor.__css;this.document.appendChild(__s); }`
 
 	if !strings.Contains(s.Src, expected) {
 		t.Error("Includes good HTML and good CSS : Unexpected source")
@@ -32,7 +41,7 @@ func TestIncludeHtml(t *testing.T) {
 
 	s.IncludeHTMLCSS("", "div { color: red; }")
 
-	expected = `function Woobly(_t_){_classCallCheck(this,Woobly);var _sr_ = _t_.attachShadow({mode:'open'});this.document = _sr_;var __s = document.createElement('style');__s.innerHTML = 'div { color: red; }';this.document.appendChild(__s);}`
+	expected = `function Woobly(_t_){_classCallCheck(this,Woobly);var _sr_ = _t_.attachShadow({mode:'open'});this.document = _sr_;var __ss = this.constructor.__ss;if (__ss && 'adoptedStyleSheets' in _sr_) { _sr_.adoptedStyleSheets = [__ss]; } else if (this.constructor.__css) { var __s = document.createElement('style');__s.innerHTML = this.constructor.__css;this.document.appendChild(__s); }}`
 	if !strings.Contains(s.Src, expected) {
 		t.Error("Includes only HTML : Unexpected source")
 	}
@@ -45,3 +54,698 @@ func TestIncludeHtml(t *testing.T) {
 		t.Error("Includes when no doc init is present : It should returns an error")
 	}
 }
+
+func TestControl(t *testing.T) {
+	tests := []struct {
+		src string
+		err error
+	}{
+		{`var Woobly = function Woobly(params) { /* this.document = document.body.shadowRoot; */ }`, engine.ErrNoDocInit},
+		{`var Woobly = function Woobly() { var s = "this.document = document.body.shadowRoot"; }`, engine.ErrNoDocInit},
+		{`var Woobly = function () { var s = "function Woobly(params) {"; this.document = document.body.shadowRoot; }`, engine.ErrNoConstructor},
+		{`// var Woobly = function Woobly() { this.document = document.body.shadowRoot; }`, engine.ErrNoClassFound},
+		{`var Woobly = function Woobly() {}; function f() { this.document = document.body.shadowRoot; }`, engine.ErrNoDocInit},
+	}
+	for i, test := range tests {
+		_, errs := engine.NewJS("objForTest", test.src, nil)
+		if len(errs) != 1 || !errors.Is(errs[0], test.err) {
+			t.Errorf("Control %d : Expected error %s, got %v", i, test.err, errs)
+		}
+	}
+
+	_, errs := engine.NewJS("objForTest", "var Woobly = function Woobly() {\n\tthis.document = document.body.shadowRoot\n\tif (true {}\n}", nil)
+	if len(errs) != 1 {
+		t.Fatalf("Control : Expected a syntax error, got %v", errs)
+	}
+	var se *ecma.SyntaxError
+	if !errors.As(errs[0], &se) || se.Line != 3 || se.Column != 11 {
+		t.Errorf("Control : Expected a syntax error at 3:11, got %s", errs[0])
+	}
+	if errs[0].Code != engine.CodeSyntax || errs[0].Range.Start.Line != 3 || errs[0].Range.Start.Column != 11 {
+		t.Errorf("Control : Unexpected syntax diagnostic %+v", errs[0])
+	}
+
+	s, errs := engine.NewJS("objForTest", `var Woobly = function () { function Woobly(params) { this.document = document.body.shadowRoot; } return Woobly; }();`, nil)
+	if len(errs) > 0 {
+		t.Errorf("Control : Valid Babel class rejected, errors %v", errs)
+	}
+	if s.GetSource() != `function () { function Woobly(params) { this.document = document.body.shadowRoot; } return Woobly; }()` {
+		t.Errorf("GetSource : Unexpected source %s", s.GetSource())
+	}
+}
+
+func TestGetSourceStatements(t *testing.T) {
+	helper := `function _classCallCheck(instance, Constructor) { if (!(instance instanceof Constructor)) { throw new TypeError("Cannot call a class as a function"); } }`
+	src := helper + "\nfunction util(a) { return a; };\nvar CONF = { a: 1 }\nvar Woobly = function Woobly() {\n  this.document = document.body.shadowRoot;\n  util(CONF.a);\n};\nvar Other = function () { _classCallCheck(this, Other); };"
+
+	s, errs := engine.NewJS("objForTest", src, nil)
+	if len(errs) > 0 {
+		t.Fatalf("The JS class is invalid, errors %v", errs)
+	}
+	if err := s.IncludeHTMLCSS("<p>hi</p>", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// The statements are kept in a function returning the class, without
+	// the helpers
+	source := s.GetSource()
+	if !strings.HasPrefix(source, "function () {\nfunction util(a) { return a; }\nvar CONF = { a: 1 }\nvar Woobly = function Woobly(_t_) {") ||
+		!strings.HasSuffix(source, "var Other = function () { _classCallCheck(this, Other); };\nreturn Woobly;\n}()") || strings.Contains(source, "function _classCallCheck") {
+		t.Errorf("GetSource : Unexpected source %s", source)
+	}
+	if helpers := s.UsedHelpers(); len(helpers) != 1 || helpers[0] != "_classCallCheck" {
+		t.Errorf("UsedHelpers : Expected the helper used out of the class, got %v", helpers)
+	}
+
+	// util(CONF.a) maps to the original source
+	origins, mappings := s.Origins()
+	at := strings.Index(source, "util(CONF.a)")
+	found := false
+	for _, m := range mappings {
+		if m.Start <= at && at < m.End {
+			found = true
+			if m.Source != "objForTest.js" || origins[0].Content[m.Offset+at-m.Start:][:12] != "util(CONF.a)" {
+				t.Errorf("Origins : Unexpected mapping %+v", m)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("Origins : util(CONF.a) not mapped in %v", mappings)
+	}
+}
+
+func TestIncludeHTMLCSSTwice(t *testing.T) {
+	src := "var Woobly = function () {\n  function Woobly (params) {\n    _classCallCheck(this, Woobly);\n    this.document = document.body.shadowRoot;\n    console.log(params);\n  }\n  return Woobly;\n}();"
+
+	s, errs := engine.NewJS("objForTest", src, nil)
+	if len(errs) > 0 {
+		t.Fatalf("The JS class is invalid, errors %v", errs)
+	}
+
+	if err := s.IncludeHTMLCSS("<p>first</p>", "p { color: red }"); err != nil {
+		t.Fatalf("First include failed, error : %s", err)
+	}
+	if err := s.IncludeHTMLCSS("<p>second</p>", ""); err != nil {
+		t.Fatalf("Second include failed, error : %s", err)
+	}
+
+	expected := "var Woobly = function () {\n  function Woobly (_t_, params) {\n    _classCallCheck(this, Woobly);\n    var _sr_ = _t_.attachShadow({mode:'open'});var __b = document.createElement('p');_sr_.appendChild(__b);var __c = document.createTextNode('second');__b.appendChild(__c);this.document = _sr_;\n    console.log(params);\n  }\n  return Woobly;\n}();"
+	if s.Src != expected {
+		t.Errorf("Includes twice : Unexpected source %s", s.Src)
+	}
+	if errs := s.Control(); len(errs) > 0 {
+		t.Errorf("Control after include : Unexpected errors %v", errs)
+	}
+
+	s, _ = engine.NewJS("objForTest", `var Woobly=function(){function Woobly(a,b){this.document=document.body.shadowRoot}return Woobly}();`, nil)
+	s.IncludeHTMLCSS("", "")
+	s.IncludeHTMLCSS("", "")
+	if !strings.Contains(s.Src, `function Woobly(_t_, a,b){var _sr_ = _t_.attachShadow({mode:'open'});this.document = _sr_;}return Woobly`) {
+		t.Errorf("Includes minified source : Unexpected source %s", s.Src)
+	}
+
+	// The user statements following the prologue are kept, whatever their
+	// names
+	src = "var Woobly = function Woobly(params) {\n  this.document = document.body.shadowRoot;\n  var __self = this;\n  __init(_sr_);\n};"
+	s, _ = engine.NewJS("objForTest", src, nil)
+	s.IncludeHTMLCSS("<p>first</p>", "p { color: red }")
+	s.IncludeHTMLCSS("<p>second</p>", "p { color: blue }")
+	if !strings.Contains(s.Src, "this.document.appendChild(__s); }\n  var __self = this;\n  __init(_sr_);\n};") || strings.Contains(s.Src, "first") {
+		t.Errorf("Includes before user code : Unexpected source %s", s.Src)
+	}
+}
+
+func TestIncludeHTMLCSSPolicy(t *testing.T) {
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+	markup := `<p onclick="steal()" class="a">hi<script>steal()</script></p>` +
+		`<a href=" JaVa&#x09;script:steal()">x</a><a href="/page" data-id="1">y</a><a href="https://wooble.io">z</a>`
+
+	tests := []struct {
+		preset   doc.Preset
+		stripped []string
+	}{
+		{doc.None, nil},
+		{doc.Strict, []string{
+			`attribute onclick="steal()" removed from <p>`,
+			`element <script> removed`,
+			"attribute href=\" JaVa\tscript:steal()\" removed from <a>",
+			`attribute data-id="1" removed from <a>`,
+		}},
+		{doc.Relaxed, []string{
+			`attribute onclick="steal()" removed from <p>`,
+			`element <script> removed`,
+			"attribute href=\" JaVa\tscript:steal()\" removed from <a>",
+		}},
+	}
+	for _, test := range tests {
+		s, _ := engine.NewJS("objForTest", src, nil)
+		s.SetPolicy(doc.NewPolicy(test.preset))
+		if err := s.IncludeHTMLCSS(markup, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		stripped := s.Stripped()
+		if len(stripped) != len(test.stripped) {
+			t.Fatalf("Policy %s : Expected %d warnings, got %v", test.preset, len(test.stripped), stripped)
+		}
+		for i, d := range stripped {
+			if d.Message != test.stripped[i] || d.Severity != engine.SeverityWarning || !errors.Is(d, engine.ErrStripped) {
+				t.Errorf("Policy %s : Unexpected warning %s", test.preset, d)
+			}
+			if expected := "objForTest: warning: " + test.stripped[i] + " [stripped]"; d.Error() != expected {
+				t.Errorf("Policy %s : Expected the warning without position %s, got %s", test.preset, expected, d)
+			}
+		}
+		if test.preset != doc.None && (strings.Contains(s.Src, "steal") || !strings.Contains(s.Src, "'https://wooble.io'")) {
+			t.Errorf("Policy %s : Unexpected source %s", test.preset, s.Src)
+		}
+	}
+
+	// The scheme of a bound URL is only known at mount time
+	params := []engine.JSParam{{Field: "link", Value: "''"}}
+	for _, test := range []struct {
+		strategy engine.Strategy
+		expected string
+	}{
+		{engine.NodeByNode, "setAttribute('href', __u(__v('link')))"},
+		{engine.Template, "__a.value = __ua.hasOwnProperty(__a.name) ? __u(__r(__a.value)) : __r(__a.value);"},
+	} {
+		for _, preset := range []doc.Preset{doc.None, doc.Strict} {
+			s, _ := engine.NewJS("objForTest", src, params)
+			s.Strategy = test.strategy
+			s.SetPolicy(doc.NewPolicy(preset))
+			if err := s.IncludeHTMLCSS(`<a href="{{link}}">x</a>`, ""); err != nil {
+				t.Fatal(err)
+			}
+			if checked := strings.Contains(s.Src, test.expected); checked != (preset != doc.None) {
+				t.Errorf("Policy %s, strategy %d : Unexpected source %s", preset, test.strategy, s.Src)
+			}
+		}
+	}
+}
+
+func TestParams(t *testing.T) {
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+	min, max := 1.0, 3.0
+	valid := []engine.JSParam{
+		{Field: "legacy", Value: "'raw'"},
+		{Field: "title", Type: engine.ParamString, Default: "</script>\u2028", Rules: &engine.ParamRules{Pattern: "[^0-9]*"}},
+		{Field: "size", Type: engine.ParamNumber, Default: 2, Rules: &engine.ParamRules{Min: &min, Max: &max}},
+		{Field: "color", Type: engine.ParamColor, Default: "#c00"},
+		{Field: "align", Type: engine.ParamEnum, Default: "left", Rules: &engine.ParamRules{Enum: []interface{}{"left", "right"}}},
+		{Field: "link", Type: engine.ParamURL, Required: true},
+		{Field: "tags", Type: engine.ParamArray, Default: []string{"a"}, Rules: &engine.ParamRules{Items: engine.ParamString, Max: &max}},
+		{Field: "data", Type: engine.ParamObject, Default: map[string]int{"a": 1}},
+	}
+	s, diags := engine.NewJS("objForTest", src, valid)
+	if len(diags) > 0 {
+		t.Fatalf("Params : Valid parameters rejected, errors %v", diags)
+	}
+	var literals []string
+	for _, p := range s.Params {
+		l, err := p.Literal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		literals = append(literals, l)
+	}
+	expected := `'raw'|"\u003c/script\u003e\u2028"|2|"#c00"|"left"|null|["a"]|{"a":1}`
+	if got := strings.Join(literals, "|"); got != expected {
+		t.Errorf("Literal : Got %s, expected %s", got, expected)
+	}
+	schema, err := s.GetSchema()
+	if err != nil || !strings.Contains(schema, `"link":{"type":"url","required":true}`) ||
+		!strings.Contains(schema, `"size":{"type":"number","min":1,"max":3}`) || strings.Contains(schema, "legacy") {
+		t.Errorf("GetSchema : Unexpected schema %s, error %v", schema, err)
+	}
+
+	for _, test := range []struct {
+		param engine.JSParam
+		msg   string
+	}{
+		{engine.JSParam{Field: "a-b", Value: "1"}, "the field must be a JavaScript identifier"},
+		{engine.JSParam{Field: "a", Type: "date"}, `unknown type "date"`},
+		{engine.JSParam{Field: "a", Type: engine.ParamNumber, Default: "2"}, "default: expected a number"},
+		{engine.JSParam{Field: "a", Type: engine.ParamNumber, Default: 5, Rules: &engine.ParamRules{Max: &max}}, "default: expected at most 3"},
+		{engine.JSParam{Field: "a", Type: engine.ParamString, Default: "abcd", Rules: &engine.ParamRules{Max: &max}}, "default: expected a length of at most 3"},
+		{engine.JSParam{Field: "a", Type: engine.ParamColor, Default: "red;x:y"}, "default: expected a color"},
+		{engine.JSParam{Field: "a", Type: engine.ParamURL, Default: " javascript:alert(1)"}, "default: expected a relative, http or https URL"},
+		{engine.JSParam{Field: "a", Type: engine.ParamEnum, Default: "up", Rules: &engine.ParamRules{Enum: []interface{}{"left", 1}}}, `default: expected one of "left",1`},
+		{engine.JSParam{Field: "a", Type: engine.ParamEnum}, "an enum has no values"},
+		{engine.JSParam{Field: "a", Type: engine.ParamArray, Default: []interface{}{1, "b"}, Rules: &engine.ParamRules{Items: engine.ParamNumber}}, "default: element 1: expected a number"},
+		{engine.JSParam{Field: "a", Type: engine.ParamString, Rules: &engine.ParamRules{Pattern: "(?i)a"}}, "invalid pattern: flags and named groups are not supported by JavaScript"},
+		{engine.JSParam{Field: "a", Type: engine.ParamBoolean, Rules: &engine.ParamRules{Min: &min}}, "min and max do not apply to a boolean"},
+		{engine.JSParam{Field: "a", Type: engine.ParamString, Default: "b", Required: true}, "a required parameter has no default"},
+		{engine.JSParam{Field: "a", Value: "1", Required: true}, "an untyped parameter has no default, rules or required flag"},
+	} {
+		_, diags := engine.NewJS("objForTest", src, []engine.JSParam{test.param})
+		if len(diags) != 1 || !errors.Is(diags[0], engine.ErrInvalidParam) || diags[0].Code != engine.CodeParams ||
+			diags[0].Message != "parameter "+test.param.Field+": "+test.msg {
+			t.Errorf("Params %+v : Expected %q, got %v", test.param, test.msg, diags)
+		}
+	}
+
+	_, diags = engine.NewJS("objForTest", src, []engine.JSParam{{Field: "a", Value: "1"}, {Field: "a", Value: "2"}})
+	if len(diags) != 1 || diags[0].Message != "parameter a: declared twice" {
+		t.Errorf("Params declared twice : Unexpected diagnostics %v", diags)
+	}
+}
+
+func TestIncludeCSS(t *testing.T) {
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+	s, _ := engine.NewJS("objForTest", src, nil)
+
+	if err := s.IncludeHTMLCSS("<p>hello</p>", "$c: red;\n.card {\n  color: $c;\n  &:hover { color: blue }\n}"); err != nil {
+		t.Fatal(err)
+	}
+	if style := s.GetStyle(); style != `'.card{color:red}.card:hover{color:blue}'` {
+		t.Errorf("Includes nested CSS : Unexpected style sheet %s", style)
+	}
+
+	err := s.IncludeHTMLCSS("<p>hello</p>", ".card {\n  color: $accent;\n}")
+	var d *engine.Diagnostic
+	if !errors.As(err, &d) || d.Code != engine.CodeCSS || d.Name != "objForTest" || d.Range.Start.Line != 2 || d.Range.Start.Column != 10 {
+		t.Fatalf("Includes invalid CSS : Unexpected error %v", err)
+	}
+	if style := s.GetStyle(); style != `'.card{color:red}.card:hover{color:blue}'` {
+		t.Errorf("Includes invalid CSS : The previous style sheet should be kept, got %s", style)
+	}
+}
+
+func TestIncludeBindings(t *testing.T) {
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+	params := []engine.JSParam{{Field: "title", Value: "'Hello'"}, {Field: "color", Value: "'red'"}}
+	markup := `<h1 title="{{ title }}">Hi {{title}}!</h1>`
+	style := "h1 { color: var(--param-color, blue) }"
+
+	s, _ := engine.NewJS("objForTest", src, params)
+	s.Strategy = engine.NodeByNode
+	if err := s.IncludeHTMLCSS(markup, style); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"var __p = arguments[1] || {}",
+		"setAttribute('title', __v('title'))",
+		"createTextNode('Hi ' + __v('title') + '!')",
+		"_t_.style.setProperty('--param-color', __v('color'))",
+	} {
+		if !strings.Contains(s.Src, expected) {
+			t.Errorf("Bindings node by node : Expected %s in %s", expected, s.Src)
+		}
+	}
+	if diags := s.Control(); len(diags) > 0 {
+		t.Errorf("Bindings of known parameters : Unexpected diagnostics %v", diags)
+	}
+
+	// The variables of the nodes do not shadow the bindings
+	s.SetPolicy(doc.NewPolicy(doc.Strict))
+	if err := s.IncludeHTMLCSS(strings.Repeat("<b>{{title}}</b>", 12), ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"__p", "__v", "__u"} {
+		if strings.Count(s.Src, "var "+v+" = ")+strings.Count(s.Src, ", "+v+" = ") != 1 {
+			t.Errorf("Bindings of many nodes : Expected one %s in %s", v, s.Src)
+		}
+	}
+	s.SetPolicy(nil)
+
+	s.Strategy = engine.Template
+	if err := s.IncludeHTMLCSS(markup, style); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s.Src, "{{title}}") || !strings.Contains(s.Src, "document.createTreeWalker(__f, 5)") {
+		t.Errorf("Bindings from a template : Unexpected source %s", s.Src)
+	}
+
+	s, _ = engine.NewJS("objForTest", src, params[:1])
+	err := s.IncludeHTMLCSS("<p>{{title}}</p>\n<p>{{ subtitle }}</p>", "p {\n  color: var(--param-color);\n}")
+	if !errors.Is(err, engine.ErrUnknownPlaceholder) {
+		t.Errorf("Bindings of unknown parameters : Expected %s, got %v", engine.ErrUnknownPlaceholder, err)
+	}
+	diags := s.Control()
+	if len(diags) != 2 {
+		t.Fatalf("Bindings of unknown parameters : Expected 2 diagnostics, got %v", diags)
+	}
+	for i, expected := range []struct {
+		msg          string
+		line, column int
+	}{
+		{"unknown parameter subtitle bound in the HTML", 2, 4},
+		{"unknown parameter color bound in the CSS", 2, 14},
+	} {
+		d := diags[i]
+		if !errors.Is(d, engine.ErrUnknownPlaceholder) || d.Code != engine.CodePlaceholder || d.Message != expected.msg ||
+			d.Range.Start.Line != expected.line || d.Range.Start.Column != expected.column {
+			t.Errorf("Bindings of unknown parameters : Unexpected diagnostic %+v", d)
+		}
+	}
+
+	// The placeholders of the comments and of the stripped markup are not
+	// bindings
+	s, _ = engine.NewJS("objForTest", src, params[:1])
+	s.SetPolicy(doc.NewPolicy(doc.Strict))
+	if err := s.IncludeHTMLCSS("<!-- {{draft}} --><script>{{code}}</script><p>{{title}}</p>", ""); err != nil {
+		t.Errorf("Bindings of sanitized markup : Unexpected error %v", err)
+	}
+	s.SetPolicy(nil)
+	err = s.IncludeHTMLCSS("<!-- {{draft}} -->\n<p>{{ draft }}</p>", "")
+	if d, ok := err.(*engine.Diagnostic); !ok || d.Range.Start.Line != 2 || d.Range.Start.Column != 4 {
+		t.Errorf("Bindings after a comment : Unexpected error %+v", err)
+	}
+}
+
+func TestIncludeSVG(t *testing.T) {
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+	s, _ := engine.NewJS("objForTest", src, nil)
+
+	icon := `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 24 24">` +
+		`<use xlink:href="#star" xml:lang="en"/><path d="M0 0h24"/></svg><math><mi>x</mi></math>`
+	if err := s.IncludeHTMLCSS(icon, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `var __b = document.createElementNS('http://www.w3.org/2000/svg', 'svg');` +
+		`__b.setAttributeNS('http://www.w3.org/2000/xmlns/', 'xmlns', 'http://www.w3.org/2000/svg');` +
+		`__b.setAttributeNS('http://www.w3.org/2000/xmlns/', 'xmlns:xlink', 'http://www.w3.org/1999/xlink');` +
+		`__b.setAttribute('viewBox', '0 0 24 24');_sr_.appendChild(__b);` +
+		`var __c = document.createElementNS('http://www.w3.org/2000/svg', 'use');` +
+		`__c.setAttributeNS('http://www.w3.org/1999/xlink', 'xlink:href', '#star');` +
+		`__c.setAttributeNS('http://www.w3.org/XML/1998/namespace', 'xml:lang', 'en');__b.appendChild(__c);` +
+		`var __d = document.createElementNS('http://www.w3.org/2000/svg', 'path');__d.setAttribute('d', 'M0 0h24');__b.appendChild(__d);` +
+		`var __e = document.createElementNS('http://www.w3.org/1998/Math/MathML', 'math');_sr_.appendChild(__e);` +
+		`var __f = document.createElementNS('http://www.w3.org/1998/Math/MathML', 'mi');__e.appendChild(__f);`
+	if !strings.Contains(s.Src, expected) {
+		t.Errorf("Includes SVG : Unexpected source %s", s.Src)
+	}
+}
+
+// generatedDOM evaluates the prologue of a creation source, it returns the
+// strings given to the DOM: texts and attribute values.
+func generatedDOM(t *testing.T, src string) (texts []string, attrs []string) {
+	prog, err := ecma.Parse(src)
+	if err != nil {
+		t.Fatalf("Generated source does not parse, error %s\n%s", err, src)
+	}
+	ecma.Inspect(prog, func(n ecma.Node) bool {
+		switch x := n.(type) {
+		case *ecma.CallExpr:
+			m, ok := x.Callee.(*ecma.MemberExpr)
+			if !ok {
+				break
+			}
+			switch m.Prop.(*ecma.Ident).Name {
+			case "createTextNode":
+				texts = append(texts, boundString(x.Args[0]))
+			case "setAttribute":
+				attrs = append(attrs, boundString(x.Args[1]))
+			}
+		}
+		return true
+	})
+	return texts, attrs
+}
+
+// boundString returns the value of a string bound to the parameters, the
+// parameters are written back as placeholders.
+func boundString(x ecma.Expr) string {
+	switch x := x.(type) {
+	case *ecma.BinaryExpr:
+		return boundString(x.X) + boundString(x.Y)
+	case *ecma.CallExpr:
+		return "{{" + x.Args[0].(*ecma.Literal).Value + "}}"
+	}
+	return x.(*ecma.Literal).Value
+}
+
+// stringValue decodes a JavaScript string literal.
+func stringValue(t *testing.T, lit string) string {
+	prog, err := ecma.Parse("x = " + lit)
+	if err != nil {
+		t.Fatalf("String %s does not parse, error %s", lit, err)
+	}
+	var value string
+	ecma.Inspect(prog, func(n ecma.Node) bool {
+		if l, ok := n.(*ecma.Literal); ok {
+			value = l.Value
+		}
+		return true
+	})
+	return value
+}
+
+func TestIncludeTemplate(t *testing.T) {
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+	markups := []string{
+		"<div class='heelo' id='hello'>test</div>",
+		"<p>a <b>bold</b>  move<!-- note --> on</p><pre>\n\nkept  </pre><textarea>\nt</textarea>",
+		"<table><tr><td>1</td></tr></table><ul><li>one<li>two</ul><img src=x alt=''>",
+		`<svg xmlns:xlink="http://www.w3.org/1999/xlink"><use xlink:href="#star"/><path d="M0 0"/></svg><math><mi>x</mi></math>`,
+		"<style>p > a { color: red }</style><p title='&lt;/script&gt;'>&amp; &lt;tag&gt;</p>",
+		strings.Repeat("<li>item</li>", engine.TemplateThreshold),
+	}
+
+	for _, markup := range markups {
+		byNode, _ := engine.NewJS("objForTest", src, nil)
+		byNode.Strategy = engine.NodeByNode
+		byTemplate, _ := engine.NewJS("objForTest", src, nil)
+		byTemplate.Strategy = engine.Template
+		if err := byNode.IncludeHTMLCSS(markup, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := byTemplate.IncludeHTMLCSS(markup, ""); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(byTemplate.Src, "document.createElement('template')") {
+			t.Errorf("Template %q : Unexpected source %s", markup, byTemplate.Src)
+		}
+		if got, want := renderedDOM(t, byTemplate.Src), renderedDOM(t, byNode.Src); got != want {
+			t.Errorf("Template %q : Got DOM %q, want %q", markup, got, want)
+		}
+	}
+
+	auto, _ := engine.NewJS("objForTest", src, nil)
+	auto.IncludeHTMLCSS("<p>small</p>", "")
+	if strings.Contains(auto.Src, "template") {
+		t.Errorf("Auto below the threshold : Unexpected source %s", auto.Src)
+	}
+	auto.IncludeHTMLCSS(markups[len(markups)-1], "")
+	if !strings.Contains(auto.Src, "template") || strings.Contains(auto.Src, "createTextNode") {
+		t.Errorf("Auto from the threshold : Unexpected source %s", auto.Src)
+	}
+}
+
+// renderedDOM evaluates the prologue of a creation source, and renders the
+// shadow root it builds.
+func renderedDOM(t *testing.T, src string) string {
+	prog, err := ecma.Parse(src)
+	if err != nil {
+		t.Fatalf("Generated source does not parse, error %s\n%s", err, src)
+	}
+	prefixes := map[string]string{
+		"http://www.w3.org/2000/svg":           "svg",
+		"http://www.w3.org/1998/Math/MathML":   "math",
+		"http://www.w3.org/2000/xmlns/":        "xmlns",
+		"http://www.w3.org/1999/xlink":         "xlink",
+		"http://www.w3.org/XML/1998/namespace": "xml",
+	}
+	str := func(x ecma.Expr) string { return x.(*ecma.Literal).Value }
+	root := &xhtml.Node{Type: xhtml.DocumentNode}
+	nodes := map[string]*xhtml.Node{"_sr_": root}
+	var tpl []*xhtml.Node
+
+	ecma.Inspect(prog, func(n ecma.Node) bool {
+		switch x := n.(type) {
+		case *ecma.VarBinding:
+			call, ok := x.Init.(*ecma.CallExpr)
+			if !ok {
+				break
+			}
+			node := &xhtml.Node{}
+			switch call.Callee.(*ecma.MemberExpr).Prop.(*ecma.Ident).Name {
+			case "createElement":
+				node.Type, node.Data = xhtml.ElementNode, str(call.Args[0])
+			case "createElementNS":
+				node.Type, node.Data, node.Namespace = xhtml.ElementNode, str(call.Args[1]), prefixes[str(call.Args[0])]
+			case "createTextNode":
+				node.Type, node.Data = xhtml.TextNode, str(call.Args[0])
+			case "createComment":
+				node.Type, node.Data = xhtml.CommentNode, str(call.Args[0])
+			default:
+				return true
+			}
+			nodes[x.Name.Name] = node
+		case *ecma.AssignExpr:
+			m, ok := x.Left.(*ecma.MemberExpr)
+			if ok && m.Prop.(*ecma.Ident).Name == "innerHTML" && isIdent(m.Object, "__tp") {
+				ctx := &xhtml.Node{Type: xhtml.ElementNode, Data: "template", DataAtom: atom.Template}
+				if tpl, err = xhtml.ParseFragment(strings.NewReader(str(x.Right)), ctx); err != nil {
+					t.Fatal(err)
+				}
+			}
+		case *ecma.CallExpr:
+			m, ok := x.Callee.(*ecma.MemberExpr)
+			if !ok || !isIdent(m.Object, "") {
+				break
+			}
+			node := nodes[m.Object.(*ecma.Ident).Name]
+			if node == nil {
+				break
+			}
+			switch m.Prop.(*ecma.Ident).Name {
+			case "setAttribute":
+				node.Attr = append(node.Attr, xhtml.Attribute{Key: str(x.Args[0]), Val: str(x.Args[1])})
+			case "setAttributeNS":
+				attr := xhtml.Attribute{Key: str(x.Args[1]), Val: str(x.Args[2])}
+				if i := strings.IndexByte(attr.Key, ':'); i >= 0 {
+					attr.Namespace, attr.Key = attr.Key[:i], attr.Key[i+1:]
+				}
+				node.Attr = append(node.Attr, attr)
+			case "appendChild":
+				if child, ok := x.Args[0].(*ecma.Ident); ok {
+					node.AppendChild(nodes[child.Name])
+					break
+				}
+				for _, c := range tpl {
+					node.AppendChild(c)
+				}
+			}
+		}
+		return true
+	})
+
+	var b strings.Builder
+	if err := xhtml.Render(&b, root); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+// isIdent reports whether x is the identifier name, or any identifier if
+// name is empty.
+func isIdent(x ecma.Expr, name string) bool {
+	id, ok := x.(*ecma.Ident)
+	return ok && (name == "" || id.Name == name)
+}
+
+func BenchmarkIncludeHTMLCSS(b *testing.B) {
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+	for _, size := range []int{4, 64, 512} {
+		markup := strings.Repeat(`<li class="item"><a href="#top">item <b>bold</b></a></li>`, size/4)
+		for _, strategy := range []struct {
+			name string
+			s    engine.Strategy
+		}{{"node", engine.NodeByNode}, {"template", engine.Template}} {
+			b.Run(fmt.Sprintf("%s/%d", strategy.name, size), func(b *testing.B) {
+				var out int
+				for i := 0; i < b.N; i++ {
+					s, _ := engine.NewJS("bench", src, nil)
+					s.Strategy = strategy.s
+					if err := s.IncludeHTMLCSS(markup, ""); err != nil {
+						b.Fatal(err)
+					}
+					out = len(s.Src)
+				}
+				b.ReportMetric(float64(out), "src-bytes")
+			})
+		}
+	}
+}
+
+var cssEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\a `, "\f", `\c `)
+
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_$][\w$]*)\s*\}\}`)
+
+func FuzzIncludeHTMLCSS(f *testing.F) {
+	for _, seed := range []string{"l'\u00e9t\u00e9", `say "hi"`, `back\slash`, "</script><script>alert(1)</script>", "<!--", "\u2028\u2029", "\x7f\x01", "body", "  two\tspaces\n\n", "hi {{ name }}{{x}}"} {
+		f.Add(seed, seed, seed)
+	}
+	src := `var Woobly=function Woobly(){this.document=document.body.shadowRoot};`
+
+	f.Fuzz(func(t *testing.T, text string, attr string, css string) {
+		// The parser replaces NUL and invalid UTF-8, and normalizes the
+		// carriage returns
+		for _, s := range []string{text, attr, css} {
+			if !utf8.ValidString(s) || strings.ContainsAny(s, "\x00\r") {
+				t.Skip()
+			}
+		}
+		// A new line following <pre> is dropped
+		if text == "" || text[0] == '\n' {
+			t.Skip()
+		}
+
+		s, _ := engine.NewJS("fuzz", src, nil)
+		// White spaces are kept verbatim in pre
+		markup := "<pre title=\"" + html.EscapeString(attr) + "\">" + html.EscapeString(text) + "</pre>"
+		// The CSS string is kept as written by the compiler
+		sheet := `p::before{content:"` + cssEscaper.Replace(css) + `"}`
+		// The creation has no parameters, the markup is included anyway
+		if err := s.IncludeHTMLCSS(markup, sheet); err != nil && !errors.Is(err, engine.ErrUnknownPlaceholder) {
+			t.Fatal(err)
+		}
+		if strings.Contains(strings.ToLower(s.Src), "</script") {
+			t.Errorf("Generated source closes the script element %s", s.Src)
+		}
+
+		// Placeholders are bound to the parameters
+		text, attr = placeholder.ReplaceAllString(text, "{{$1}}"), placeholder.ReplaceAllString(attr, "{{$1}}")
+		texts, attrs := generatedDOM(t, s.Src)
+		if len(texts) != 1 || texts[0] != text {
+			t.Errorf("Text %q : Unexpected text nodes %q", text, texts)
+		}
+		if len(attrs) != 1 || attrs[0] != attr {
+			t.Errorf("Attribute %q : Unexpected attributes %q", attr, attrs)
+		}
+		if style := s.GetStyle(); stringValue(t, style) != sheet {
+			t.Errorf("CSS %q : Unexpected style sheet %s", css, style)
+		}
+		if strings.Contains(strings.ToLower(s.GetStyle()), "</style") {
+			t.Errorf("Style sheet closes the style element %s", s.GetStyle())
+		}
+	})
+}
+
+func TestHelpers(t *testing.T) {
+	// Babel output, with its own formatting of the helpers
+	src := `"use strict";
+
+var _createClass = function () {
+  function defineProperties(target, props) { for (var i = 0; i < props.length; i++) { var descriptor = props[i]; descriptor.enumerable = descriptor.enumerable || false; descriptor.configurable = true; if ("value" in descriptor) descriptor.writable = true; Object.defineProperty(target, descriptor.key, descriptor); } }
+  return function (Constructor, protoProps, staticProps) { if (protoProps) defineProperties(Constructor.prototype, protoProps); if (staticProps) defineProperties(Constructor, staticProps); return Constructor; };
+}();
+
+function _classCallCheck(inst, Ctor) {
+  if (!(inst instanceof Ctor)) {
+    throw new TypeError("Cannot call a class as a function");
+  }
+}
+
+var Woobly = function () {
+  function Woobly(params) {
+    _classCallCheck(this, Woobly);
+    this.document = document.body.shadowRoot;
+    this._inherits = params._extends;
+  }
+
+  _createClass(Woobly, [{ key: "m", value: function m(a) { return _typeof(a); } }]);
+
+  return Woobly;
+}();`
+	js, errs := engine.NewJS("helped", src, nil)
+	if len(errs) > 0 {
+		t.Fatalf("Control : Standard helpers rejected, errors %v", errs)
+	}
+	if used := js.UsedHelpers(); strings.Join(used, ",") != "_typeof,_createClass,_classCallCheck" {
+		t.Errorf("UsedHelpers : Unexpected helpers %v", used)
+	}
+	if strings.Contains(js.GetSource(), "defineProperties") {
+		t.Errorf("GetSource : The helpers should be stripped %s", js.GetSource())
+	}
+
+	custom := strings.Replace(src, `throw new TypeError("Cannot call a class as a function");`, "", 1)
+	_, errs = engine.NewJS("helped", custom, nil)
+	if len(errs) != 1 || !errors.Is(errs[0], engine.ErrHelper) || errs[0].Severity != engine.SeverityWarning ||
+		errs[0].Range.Start.Line != 8 || errs[0].Message != "_classCallCheck is not the standard Babel helper, the standard one replaces it" {
+		t.Errorf("Control : Expected a helper warning, got %v", errs)
+	}
+}
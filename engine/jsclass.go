@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"github.com/woobleio/wooblizer/engine/ecma"
+)
+
+// JSClass is a native ES2015 class creation, it needs no Babel pre-step.
+//
+//	class Woobly {
+//		constructor(params) {
+//			this.document = document.body.shadowRoot;
+//		}
+//	}
+type JSClass struct {
+	creation
+}
+
+var classShape = shape{
+	locateClass,
+	"no top-level class " + StdName + " declaration found",
+	"class " + StdName + " has no constructor",
+}
+
+// NewJSClass initializes a native ES2015 class creation
+// name: creation's name
+// src: source code
+// params: creation's parameters
+func NewJSClass(name string, src string, params []JSParam) (*JSClass, []*Diagnostic) {
+	js := &JSClass{creation{
+		Name:   name,
+		Src:    src,
+		Params: params,
+		shape:  classShape,
+		orig:   src,
+	}}
+
+	return js, js.Control()
+}
+
+// NewJSClassScript is NewJSClass taking decoded parameters, it is the factory
+// of the class engine.
+func NewJSClassScript(name string, src string, params []interface{}) (Script, []*Diagnostic) {
+	return NewJSClass(name, src, toJSParams(params))
+}
+
+func locateClass(prog *ecma.Program) (ecma.Node, *ecma.Func) {
+	for _, st := range prog.Body {
+		decl, ok := st.(*ecma.ClassDecl)
+		if !ok || decl.Class.Name.Name != StdName {
+			continue
+		}
+		for _, m := range decl.Class.Methods {
+			if m.Kind == "constructor" {
+				return decl.Class, m.Value
+			}
+		}
+		return decl.Class, nil
+	}
+	return nil, nil
+}
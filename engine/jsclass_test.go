@@ -0,0 +1,62 @@
+package engine_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/woobleio/wooblizer/engine"
+)
+
+func TestJSClass(t *testing.T) {
+	src := "class Woobly extends Base {\n  constructor(params) {\n    super(params);\n    this.document = document.body.shadowRoot;\n  }\n  get size() { return 1; }\n}"
+
+	s, errs := engine.NewJSClass("objForTest", src, nil)
+	if len(errs) > 0 {
+		t.Fatalf("The class is invalid, errors %v", errs)
+	}
+	if s.GetSource() != src {
+		t.Errorf("GetSource : Unexpected source %s", s.GetSource())
+	}
+
+	if err := s.IncludeHTMLCSS("<p>hello</p>", "p { color: red }"); err != nil {
+		t.Fatalf("Include failed, error : %s", err)
+	}
+	expected := "  constructor(_t_, params) {\n    super(params);\n    var _sr_ = _t_.attachShadow({mode:'open'});var __b = document.createElement('p');_sr_.appendChild(__b);var __c = document.createTextNode('hello');__b.appendChild(__c);this.document = _sr_;var __ss = this.constructor.__ss;if (__ss && 'adoptedStyleSheets' in _sr_) { _sr_.adoptedStyleSheets = [__ss]; } else if (this.constructor.__css) { var __s = document.createElement('style');__s.innerHTML = this.constructor.__css;this.document.appendChild(__s); }\n  }"
+	if !strings.Contains(s.Src, expected) {
+		t.Errorf("Includes HTML and CSS : Unexpected source %s", s.Src)
+	}
+	if errs := s.Control(); len(errs) > 0 {
+		t.Errorf("Control after include : Unexpected errors %v", errs)
+	}
+}
+
+func TestJSClassControl(t *testing.T) {
+	tests := []struct {
+		src string
+		err error
+	}{
+		{`class Woobly { constructor(params) {} }`, engine.ErrNoDocInit},
+		{`class Woobly { init() { this.document = document.body.shadowRoot; } }`, engine.ErrNoConstructor},
+		{`class Foobar { constructor() { this.document = document.body.shadowRoot; } }`, engine.ErrNoClassFound},
+		{`var Woobly = function Woobly() { this.document = document.body.shadowRoot; };`, engine.ErrNoClassFound},
+	}
+	for i, test := range tests {
+		_, errs := engine.NewJSClass("objForTest", test.src, nil)
+		if len(errs) != 1 || !errors.Is(errs[0], test.err) {
+			t.Errorf("Control %d : Expected error %s, got %v", i, test.err, errs)
+		}
+	}
+}
+
+func TestJSClassUnsupported(t *testing.T) {
+	src := "class Woobly {\n  constructor(params) {\n    this.document = document.body.shadowRoot;\n    let total = 0;\n  }\n}"
+	_, errs := engine.NewJSClass("objForTest", src, nil)
+	if len(errs) != 1 || errs[0].Code != engine.CodeUnsupported || errs[0].Range.Start.Line != 4 {
+		t.Fatalf("Control : Expected an unsupported feature diagnostic, got %v", errs)
+	}
+	expected := "objForTest:4:5: error: unsupported ES2015 feature: let declarations, compile the creation to ES5 with Babel [unsupported]"
+	if errs[0].Error() != expected {
+		t.Errorf("Control : Expected %s, got %s", expected, errs[0])
+	}
+}
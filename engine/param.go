@@ -0,0 +1,438 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+// ParamType is the type of a typed creation parameter.
+type ParamType string
+
+// Parameter types
+const (
+	ParamString  ParamType = "string"
+	ParamNumber  ParamType = "number"
+	ParamBoolean ParamType = "boolean"
+	ParamColor   ParamType = "color" // hexadecimal, rgb(), hsl() or a keyword
+	ParamEnum    ParamType = "enum"  // one of the values of the Enum rule
+	ParamURL     ParamType = "url"   // relative, http or https
+	ParamArray   ParamType = "array"
+	ParamObject  ParamType = "object"
+)
+
+var paramTypes = map[ParamType]bool{
+	ParamString: true, ParamNumber: true, ParamBoolean: true, ParamColor: true,
+	ParamEnum: true, ParamURL: true, ParamArray: true, ParamObject: true,
+}
+
+// ParamRules constrain the values of a typed parameter, the runtime checks
+// the values given to init with the same rules.
+type ParamRules struct {
+	// Min and Max bound a number, or the length of a string or an array. The
+	// length of a string counts UTF-16 code units, as JavaScript does.
+	Min *float64
+	Max *float64
+
+	// Pattern is a regular expression matching a whole string, in the syntax
+	// shared by Go and JavaScript
+	Pattern string
+
+	// Enum are the values of an enum, strings, numbers or booleans
+	Enum []interface{}
+
+	// Items is the type of the elements of an array, any type if empty
+	Items ParamType
+}
+
+// Fields are JavaScript identifiers, they are bound in the markup and quoted
+// in the library
+var fieldName = regexp.MustCompile(`^[A-Za-z_$][\w$]*$`)
+
+var (
+	colorValue = regexp.MustCompile(`(?i)^(#([0-9a-f]{3,4}|[0-9a-f]{6}|[0-9a-f]{8})|(rgb|hsl)a?\([0-9a-z .,%/+-]*\)|[a-z]+)$`)
+	urlScheme  = regexp.MustCompile(`(?i)^([a-z][a-z0-9+.-]*):`)
+	posixClass = regexp.MustCompile(`\[:\^?[a-z]+:\]`)
+)
+
+// Literal returns the default value of the parameter in JavaScript, null if
+// a typed parameter has no default.
+func (p JSParam) Literal() (string, error) {
+	if p.Type == "" {
+		return p.Value, nil
+	}
+	if p.Default == nil {
+		return "null", nil
+	}
+	return jsLiteral(p.Default)
+}
+
+// jsLiteral serializes a Go value into a JavaScript literal. The JSON encoder
+// escapes <, > and &, so the literal cannot close a script element, and the
+// line separators U+2028 and U+2029.
+func jsLiteral(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// normalize converts a Go value into the value JavaScript reads from its
+// literal: nil, bool, float64, string, []interface{} or
+// map[string]interface{}.
+func normalize(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var n interface{}
+	err = json.Unmarshal(b, &n)
+	return n, err
+}
+
+// decodeJSParam decodes a parameter given as a map, such as a JSON object.
+func decodeJSParam(m map[string]interface{}) (JSParam, error) {
+	var p JSParam
+	t, typed := m["type"]
+	if !typed {
+		field, okField := m["field"].(string)
+		value, okValue := m["value"].(string)
+		if !okField || !okValue {
+			return p, errors.New("field and value must be strings")
+		}
+		return JSParam{Field: field, Value: value}, nil
+	}
+
+	var ok bool
+	if p.Field, ok = m["field"].(string); !ok {
+		return p, errors.New("field must be a string")
+	}
+	s, ok := t.(string)
+	if !ok {
+		return p, errors.New("type must be a string")
+	}
+	if _, ok := m["value"]; ok {
+		return p, errors.New("a typed parameter has a default, not a value")
+	}
+	p.Type = ParamType(s)
+	p.Default = m["default"]
+	if v, ok := m["required"]; ok {
+		if p.Required, ok = v.(bool); !ok {
+			return p, errors.New("required must be a boolean")
+		}
+	}
+
+	var r ParamRules
+	rules := false
+	for _, k := range []string{"min", "max"} {
+		v, ok := m[k]
+		if !ok {
+			continue
+		}
+		f, ok := number(v)
+		if !ok {
+			return p, errors.New(k + " must be a number")
+		}
+		if k == "min" {
+			r.Min = &f
+		} else {
+			r.Max = &f
+		}
+		rules = true
+	}
+	if v, ok := m["pattern"]; ok {
+		if r.Pattern, ok = v.(string); !ok {
+			return p, errors.New("pattern must be a string")
+		}
+		rules = true
+	}
+	if v, ok := m["enum"]; ok {
+		if r.Enum, ok = v.([]interface{}); !ok {
+			return p, errors.New("enum must be an array")
+		}
+		rules = true
+	}
+	if v, ok := m["items"]; ok {
+		items, ok := v.(string)
+		if !ok {
+			return p, errors.New("items must be a string")
+		}
+		r.Items = ParamType(items)
+		rules = true
+	}
+	if rules {
+		p.Rules = &r
+	}
+	return p, nil
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// paramDiagnostics reports the invalid parameters of a creation.
+func paramDiagnostics(name string, params []JSParam) []*Diagnostic {
+	var diags []*Diagnostic
+	seen := make(map[string]bool, len(params))
+	for _, p := range params {
+		err := checkParam(p)
+		if err == nil && seen[p.Field] {
+			err = errors.New("declared twice")
+		}
+		seen[p.Field] = true
+		if err != nil {
+			d := NewDiagnostic(CodeParams, ErrInvalidParam)
+			d.Message = "parameter " + p.Field + ": " + err.Error()
+			d.Name = name
+			diags = append(diags, d)
+		}
+	}
+	return diags
+}
+
+// checkParam checks the definition of a parameter, and its default against
+// its type and rules.
+func checkParam(p JSParam) error {
+	if !fieldName.MatchString(p.Field) {
+		return errors.New("the field must be a JavaScript identifier")
+	}
+	if p.Type == "" {
+		if p.Default != nil || p.Required || p.Rules != nil {
+			return errors.New("an untyped parameter has no default, rules or required flag")
+		}
+		return nil
+	}
+	if !paramTypes[p.Type] {
+		return fmt.Errorf("unknown type %q", p.Type)
+	}
+	if p.Value != "" {
+		return errors.New("a typed parameter has a default, not a value")
+	}
+	if err := checkRules(p.Type, p.Rules); err != nil {
+		return err
+	}
+	if p.Default == nil {
+		return nil
+	}
+	if p.Required {
+		return errors.New("a required parameter has no default")
+	}
+	v, err := normalize(p.Default)
+	if err != nil {
+		return fmt.Errorf("default: %s", err)
+	}
+	if msg := invalidValue(p.Type, p.Rules, v); msg != "" {
+		return errors.New("default: " + msg)
+	}
+	return nil
+}
+
+func isStringType(t ParamType) bool {
+	return t == ParamString || t == ParamColor || t == ParamURL
+}
+
+func checkRules(t ParamType, r *ParamRules) error {
+	if t == ParamEnum && (r == nil || len(r.Enum) == 0) {
+		return errors.New("an enum has no values")
+	}
+	if r == nil {
+		return nil
+	}
+	if r.Min != nil || r.Max != nil {
+		if t != ParamNumber && t != ParamArray && !isStringType(t) {
+			return fmt.Errorf("min and max do not apply to a %s", t)
+		}
+		if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return errors.New("min is greater than max")
+		}
+	}
+	if r.Pattern != "" {
+		if !isStringType(t) {
+			return fmt.Errorf("a pattern does not apply to a %s", t)
+		}
+		if err := checkPattern(r.Pattern); err != nil {
+			return err
+		}
+	}
+	if len(r.Enum) > 0 && t != ParamEnum {
+		return fmt.Errorf("values do not apply to a %s", t)
+	}
+	for _, e := range r.Enum {
+		switch v, err := normalize(e); v.(type) {
+		case string, float64, bool:
+		default:
+			if err != nil {
+				return fmt.Errorf("enum: %s", err)
+			}
+			return errors.New("the values of an enum are strings, numbers or booleans")
+		}
+	}
+	if r.Items != "" {
+		if t != ParamArray {
+			return fmt.Errorf("items do not apply to a %s", t)
+		}
+		if !paramTypes[r.Items] || r.Items == ParamEnum {
+			return fmt.Errorf("invalid items type %q", r.Items)
+		}
+	}
+	return nil
+}
+
+// checkPattern checks that a pattern compiles, and has none of the Go
+// syntax JavaScript reads differently.
+func checkPattern(pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %s", err)
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			i++
+			if strings.IndexByte("AzpPQEC", pattern[i]) >= 0 {
+				return fmt.Errorf("invalid pattern: \\%c is not supported by JavaScript", pattern[i])
+			}
+		case strings.HasPrefix(pattern[i:], "(?") && !strings.HasPrefix(pattern[i:], "(?:"):
+			return errors.New("invalid pattern: flags and named groups are not supported by JavaScript")
+		}
+	}
+	if posixClass.MatchString(pattern) {
+		return errors.New("invalid pattern: classes [:name:] are not supported by JavaScript")
+	}
+	return nil
+}
+
+// invalidValue checks a normalized value against a type and rules. It
+// returns what is wrong with the value, or "" if it is valid. The runtime
+// returns the same messages.
+func invalidValue(t ParamType, r *ParamRules, v interface{}) string {
+	var size float64
+	switch t {
+	case ParamNumber:
+		n, ok := v.(float64)
+		if !ok {
+			return "expected a number"
+		}
+		size = n
+	case ParamBoolean:
+		if _, ok := v.(bool); !ok {
+			return "expected a boolean"
+		}
+	case ParamObject:
+		if _, ok := v.(map[string]interface{}); !ok {
+			return "expected an object"
+		}
+	case ParamArray:
+		a, ok := v.([]interface{})
+		if !ok {
+			return "expected an array"
+		}
+		if r != nil && r.Items != "" {
+			for i, e := range a {
+				if msg := invalidValue(r.Items, nil, e); msg != "" {
+					return fmt.Sprintf("element %d: %s", i, msg)
+				}
+			}
+		}
+		size = float64(len(a))
+	case ParamEnum:
+		for _, e := range r.Enum {
+			if e, _ := normalize(e); e == v {
+				return ""
+			}
+		}
+		values, _ := jsLiteral(r.Enum)
+		return "expected one of " + values[1:len(values)-1]
+	default:
+		s, ok := v.(string)
+		if !ok {
+			return "expected a string"
+		}
+		if t == ParamColor && !colorValue.MatchString(s) {
+			return "expected a color"
+		}
+		if t == ParamURL && !validURL(s) {
+			return "expected a relative, http or https URL"
+		}
+		if r != nil && r.Pattern != "" && !regexp.MustCompile(`^(?:`+r.Pattern+`)$`).MatchString(s) {
+			return "expected to match " + r.Pattern
+		}
+		size = float64(len(utf16.Encode([]rune(s))))
+	}
+
+	if r == nil {
+		return ""
+	}
+	bound := "a length of "
+	if t == ParamNumber {
+		bound = ""
+	}
+	if r.Min != nil && size < *r.Min {
+		min, _ := jsLiteral(*r.Min)
+		return "expected " + bound + "at least " + min
+	}
+	if r.Max != nil && size > *r.Max {
+		max, _ := jsLiteral(*r.Max)
+		return "expected " + bound + "at most " + max
+	}
+	return ""
+}
+
+// validURL reports whether a URL is relative, or its scheme is http or
+// https. Browsers drop the tabs and new lines of a URL, and the leading
+// control characters and spaces.
+func validURL(s string) bool {
+	s = strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.TrimLeft(s, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x0b\x0c\x0e\x0f"+
+		"\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f ")
+	m := urlScheme.FindStringSubmatch(s)
+	return m == nil || strings.EqualFold(m[1], "http") || strings.EqualFold(m[1], "https")
+}
+
+// paramSchema is a typed parameter as the runtime reads it.
+type paramSchema struct {
+	Type     ParamType     `json:"type"`
+	Required bool          `json:"required,omitempty"`
+	Min      *float64      `json:"min,omitempty"`
+	Max      *float64      `json:"max,omitempty"`
+	Pattern  string        `json:"pattern,omitempty"`
+	Enum     []interface{} `json:"enum,omitempty"`
+	Items    ParamType     `json:"items,omitempty"`
+}
+
+// paramsSchema returns the schema of the typed parameters as a JavaScript
+// object, or "" if there is none.
+func paramsSchema(params []JSParam) (string, error) {
+	schema := make(map[string]paramSchema)
+	for _, p := range params {
+		if p.Type == "" {
+			continue
+		}
+		s := paramSchema{Type: p.Type, Required: p.Required}
+		if r := p.Rules; r != nil {
+			s.Min, s.Max, s.Pattern, s.Enum, s.Items = r.Min, r.Max, r.Pattern, r.Enum, r.Items
+		}
+		schema[p.Field] = s
+	}
+	if len(schema) == 0 {
+		return "", nil
+	}
+	return jsLiteral(schema)
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"sort"
+	"sync"
+)
+
+// Factory is what an engine registers: how to decode the parameters of a
+// creation, how to build its scripts, and the runtime wrapping them.
+type Factory struct {
+	// DecodeParams converts the parameters given to Wbzr.Inject into the
+	// parameters expected by New, it may be nil if they are passed as is
+	DecodeParams func(params []interface{}) ([]interface{}, error)
+
+	// New creates a script from a creation source and its parameters
+	New func(name string, src string, params []interface{}) (Script, []*Diagnostic)
+
+	// Runtime is the name of the runtime template, the format templates
+	// (esm.js, cjs.js and umd.js) include it as "runtime"
+	Runtime string
+
+	// Templates are the names of the templates included by the runtime
+	Templates []string
+
+	// Asset loads a template by name
+	Asset func(name string) ([]byte, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes an engine available by name. It panics if the name is
+// already registered or if the factory cannot build scripts.
+func Register(name string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if f.New == nil || f.Asset == nil {
+		panic("engine: Register " + name + " without New or Asset")
+	}
+	if _, dup := registry[name]; dup {
+		panic("engine: Register called twice for " + name)
+	}
+	registry[name] = f
+}
+
+// Lookup returns the engine registered by name.
+func Lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	f, ok := registry[name]
+	return f, ok
+}
+
+// Engines returns the sorted names of the registered engines.
+func Engines() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
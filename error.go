@@ -4,5 +4,10 @@ import "errors"
 
 // Wbzr errors
 var (
-	ErrUniqueName = errors.New("Object name just by unique in order to be wooblized")
+	ErrUniqueName    = errors.New("Object name just by unique in order to be wooblized")
+	ErrUnknownLang   = errors.New("Language not supported")
+	ErrDomainPattern = errors.New("Invalid domain pattern")
+	ErrPolyfill      = errors.New("Invalid polyfill")
+	ErrExportName    = errors.New("Creation name clashes in the module exports")
+	ErrElementName   = errors.New("Creation name clashes in the custom element names")
 )
@@ -0,0 +1,112 @@
+package wbzr
+
+import (
+	"github.com/woobleio/wooblizer/engine"
+)
+
+// JSONSchema is a JSON Schema (draft 2020-12) of the parameters of a
+// creation, it can be encoded with encoding/json. The encoding is
+// deterministic, the properties are sorted.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	MinLength            *float64               `json:"minLength,omitempty"`
+	MaxLength            *float64               `json:"maxLength,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	MinItems             *float64               `json:"minItems,omitempty"`
+	MaxItems             *float64               `json:"maxItems,omitempty"`
+	AllOf                []*JSONSchema          `json:"allOf,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Default              interface{}            `json:"default,omitempty"`
+}
+
+// colorPattern is the color rule of the runtime, in the ECMA-262 syntax of
+// JSON Schema patterns
+const colorPattern = `^(#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})|([rR][gG][bB]|[hH][sS][lL])[aA]?\([0-9a-zA-Z .,%/+-]*\)|[a-zA-Z]+)$`
+
+// Schemas returns the JSON Schema of the parameters of each creation, by
+// creation name. The parameters given to init are an object of the fields,
+// the others are ignored. An untyped parameter takes any value.
+func (wb *Wbzr) Schemas() map[string]*JSONSchema {
+	schemas := make(map[string]*JSONSchema, len(wb.Scripts))
+	for _, sc := range wb.Scripts {
+		closed := false
+		s := &JSONSchema{
+			Schema:               "https://json-schema.org/draft/2020-12/schema",
+			Title:                sc.GetName(),
+			Type:                 "object",
+			Properties:           make(map[string]*JSONSchema),
+			AdditionalProperties: &closed,
+		}
+		for _, p := range sc.GetParams() {
+			jp, ok := p.(engine.JSParam)
+			if !ok {
+				continue
+			}
+			s.Properties[jp.Field] = paramJSONSchema(jp)
+			if jp.Required {
+				s.Required = append(s.Required, jp.Field)
+			}
+		}
+		schemas[sc.GetName()] = s
+	}
+	return schemas
+}
+
+// paramJSONSchema returns the schema of a parameter, the values of an
+// untyped parameter are not described.
+func paramJSONSchema(p engine.JSParam) *JSONSchema {
+	if p.Type == "" {
+		return &JSONSchema{}
+	}
+	s := typeJSONSchema(p.Type)
+	s.Default = p.Default
+	r := p.Rules
+	if r == nil {
+		return s
+	}
+	switch p.Type {
+	case engine.ParamNumber:
+		s.Minimum, s.Maximum = r.Min, r.Max
+	case engine.ParamArray:
+		s.MinItems, s.MaxItems = r.Min, r.Max
+		if r.Items != "" {
+			s.Items = typeJSONSchema(r.Items)
+		}
+	case engine.ParamEnum:
+		s.Enum = r.Enum
+	default:
+		s.MinLength, s.MaxLength = r.Min, r.Max
+		if r.Pattern == "" {
+			break
+		}
+		pattern := "^(?:" + r.Pattern + ")$"
+		if s.Pattern == "" {
+			s.Pattern = pattern
+		} else {
+			s.AllOf = []*JSONSchema{{Pattern: pattern}}
+		}
+	}
+	return s
+}
+
+// typeJSONSchema returns the schema of the values of a type.
+func typeJSONSchema(t engine.ParamType) *JSONSchema {
+	switch t {
+	case engine.ParamColor:
+		return &JSONSchema{Type: "string", Pattern: colorPattern}
+	case engine.ParamURL:
+		return &JSONSchema{Type: "string", Format: "uri-reference"}
+	case engine.ParamEnum:
+		return &JSONSchema{}
+	}
+	return &JSONSchema{Type: string(t)}
+}
@@ -0,0 +1,13 @@
+package license
+
+import "errors"
+
+// License errors, the runtime reports the same messages
+var (
+	ErrMalformed  = errors.New("Malformed license")
+	ErrUnknownKey = errors.New("Unknown license key")
+	ErrSignature  = errors.New("Invalid license signature")
+	ErrExpired    = errors.New("License expired")
+	ErrDomain     = errors.New("Domain not licensed")
+	ErrKey        = errors.New("Invalid license key")
+)
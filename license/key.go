@@ -0,0 +1,175 @@
+package license
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// Algorithm is the signature algorithm of a license, named as in the JWT
+// header.
+type Algorithm string
+
+// Signature algorithms
+const (
+	// EdDSA signs with an Ed25519 private key, the library embeds the
+	// public key only
+	EdDSA Algorithm = "EdDSA"
+	// HS256 signs with an HMAC-SHA256 secret. The library embeds the secret,
+	// so anyone reading it can sign licenses for other domains.
+	HS256 Algorithm = "HS256"
+)
+
+// MinSecretSize is the minimum size in bytes of an HMAC secret.
+const MinSecretSize = 32
+
+// PEM block types of the keys
+const (
+	pemPrivate = "PRIVATE KEY"
+	pemPublic  = "PUBLIC KEY"
+	pemSecret  = "WOOBLE HMAC KEY"
+	pemKeyID   = "Key-Id"
+)
+
+// Key signs or verifies licenses. An Ed25519 key holds a private key, which
+// signs, or a public key only, which verifies. An HMAC key does both.
+type Key struct {
+	id  string
+	alg Algorithm
+
+	private ed25519.PrivateKey // nil for a public key
+	public  ed25519.PublicKey
+	secret  []byte
+}
+
+// GenerateKey generates a random key, id names it in the licenses it signs
+// so keys can be rotated. It may be empty.
+func GenerateKey(alg Algorithm, id string) (*Key, error) {
+	switch alg {
+	case EdDSA:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewEd25519Key(id, priv)
+	case HS256:
+		secret := make([]byte, MinSecretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		return NewHMACKey(id, secret)
+	}
+	return nil, fmt.Errorf("%w: unknown algorithm %q", ErrKey, alg)
+}
+
+// NewEd25519Key returns the key of an Ed25519 private key.
+func NewEd25519Key(id string, priv ed25519.PrivateKey) (*Key, error) {
+	if len(priv) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("%w: bad Ed25519 private key size %d", ErrKey, len(priv))
+	}
+	return &Key{id: id, alg: EdDSA, private: priv, public: priv.Public().(ed25519.PublicKey)}, nil
+}
+
+// NewEd25519PublicKey returns a key verifying the licenses signed by the
+// private key of pub.
+func NewEd25519PublicKey(id string, pub ed25519.PublicKey) (*Key, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: bad Ed25519 public key size %d", ErrKey, len(pub))
+	}
+	return &Key{id: id, alg: EdDSA, public: pub}, nil
+}
+
+// NewHMACKey returns the key of an HMAC-SHA256 secret of MinSecretSize bytes
+// at least.
+func NewHMACKey(id string, secret []byte) (*Key, error) {
+	if len(secret) < MinSecretSize {
+		return nil, fmt.Errorf("%w: HMAC secret of %d bytes, %d at least", ErrKey, len(secret), MinSecretSize)
+	}
+	return &Key{id: id, alg: HS256, secret: append([]byte(nil), secret...)}, nil
+}
+
+// ID returns the id of the key.
+func (k *Key) ID() string { return k.id }
+
+// Algorithm returns the algorithm of the key.
+func (k *Key) Algorithm() Algorithm { return k.alg }
+
+// CanSign reports whether the key signs licenses.
+func (k *Key) CanSign() bool { return k.private != nil || k.secret != nil }
+
+// Public returns the key verifying the licenses, the key itself for HMAC.
+func (k *Key) Public() *Key {
+	if k.alg == HS256 {
+		return k
+	}
+	return &Key{id: k.id, alg: k.alg, public: k.public}
+}
+
+// VerifyingKey returns the bytes the runtime verifies a license with: the
+// Ed25519 public key or the HMAC secret.
+func (k *Key) VerifyingKey() []byte {
+	if k.alg == HS256 {
+		return append([]byte(nil), k.secret...)
+	}
+	return append([]byte(nil), k.public...)
+}
+
+// MarshalPEM encodes the key in PEM: a PKCS #8 private key or a PKIX public
+// key for Ed25519, the raw secret for HMAC. The id is the Key-Id header.
+func (k *Key) MarshalPEM() ([]byte, error) {
+	b := &pem.Block{}
+	if k.id != "" {
+		b.Headers = map[string]string{pemKeyID: k.id}
+	}
+	var err error
+	switch {
+	case k.alg == HS256:
+		b.Type, b.Bytes = pemSecret, k.secret
+	case k.private != nil:
+		b.Type = pemPrivate
+		b.Bytes, err = x509.MarshalPKCS8PrivateKey(k.private)
+	default:
+		b.Type = pemPublic
+		b.Bytes, err = x509.MarshalPKIXPublicKey(k.public)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(b), nil
+}
+
+// ParseKey decodes a key encoded by MarshalPEM.
+func ParseKey(data []byte) (*Key, error) {
+	b, _ := pem.Decode(data)
+	if b == nil {
+		return nil, fmt.Errorf("%w: no PEM block found", ErrKey)
+	}
+	id := b.Headers[pemKeyID]
+	switch b.Type {
+	case pemSecret:
+		return NewHMACKey(id, b.Bytes)
+	case pemPrivate:
+		k, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrKey, err)
+		}
+		priv, ok := k.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T is not an Ed25519 key", ErrKey, k)
+		}
+		return NewEd25519Key(id, priv)
+	case pemPublic:
+		k, err := x509.ParsePKIXPublicKey(b.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrKey, err)
+		}
+		pub, ok := k.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T is not an Ed25519 key", ErrKey, k)
+		}
+		return NewEd25519PublicKey(id, pub)
+	}
+	return nil, fmt.Errorf("%w: unknown PEM block %q", ErrKey, b.Type)
+}
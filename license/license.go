@@ -0,0 +1,165 @@
+// Package license signs and verifies the domain licenses of Wooble
+// libraries. A license is a JWT listing the domains a library is served on,
+// its expiry and the customer it is issued to, signed with Ed25519 or
+// HMAC-SHA256. The runtime of a library verifies it the same way Verify does.
+package license
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// License grants a customer the use of a library on some domains, until it
+// expires.
+type License struct {
+	Customer string
+	Domains  []string // host names, compared case insensitively
+	Expiry   time.Time
+	IssuedAt time.Time // zero if unknown
+}
+
+// header is the JOSE header of a token.
+type header struct {
+	Alg Algorithm `json:"alg"`
+	Typ string    `json:"typ,omitempty"`
+	Kid string    `json:"kid,omitempty"`
+}
+
+// claims are the claims of a token, times are Unix times in seconds.
+type claims struct {
+	Sub     string   `json:"sub,omitempty"`
+	Domains []string `json:"domains"`
+	Exp     *float64 `json:"exp"`
+	Iat     *float64 `json:"iat,omitempty"`
+}
+
+var b64 = base64.RawURLEncoding
+
+// Allows reports whether the license allows a host name, its expiry is
+// checked by Verify.
+func (l *License) Allows(host string) bool {
+	for _, d := range l.Domains {
+		if strings.EqualFold(d, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the token of a license, signed by the key.
+func (k *Key) Sign(l *License) (string, error) {
+	if !k.CanSign() {
+		return "", fmt.Errorf("%w: %s public key cannot sign", ErrKey, k.alg)
+	}
+	if len(l.Domains) == 0 {
+		return "", errors.New("license: no domain licensed")
+	}
+	if l.Expiry.IsZero() {
+		return "", errors.New("license: no expiry")
+	}
+	exp := float64(l.Expiry.Unix())
+	c := claims{Sub: l.Customer, Domains: l.Domains, Exp: &exp}
+	if !l.IssuedAt.IsZero() {
+		iat := float64(l.IssuedAt.Unix())
+		c.Iat = &iat
+	}
+	h, err := json.Marshal(header{k.alg, "JWT", k.id})
+	if err != nil {
+		return "", err
+	}
+	p, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	signed := b64.EncodeToString(h) + "." + b64.EncodeToString(p)
+	return signed + "." + b64.EncodeToString(k.sign([]byte(signed))), nil
+}
+
+func (k *Key) sign(msg []byte) []byte {
+	if k.alg == HS256 {
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(msg)
+		return mac.Sum(nil)
+	}
+	return ed25519.Sign(k.private, msg)
+}
+
+func (k *Key) verify(msg []byte, sig []byte) bool {
+	if k.alg == HS256 {
+		return hmac.Equal(k.sign(msg), sig)
+	}
+	return ed25519.Verify(k.public, msg, sig)
+}
+
+// Verify verifies the signature of a token with the key named by its header,
+// or with the keys of its algorithm if it names none, and its expiry at now.
+// The errors are ErrMalformed, ErrUnknownKey, ErrSignature and ErrExpired.
+func Verify(token string, now time.Time, keys ...*Key) (*License, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+	var h header
+	var c claims
+	if err := decode(parts[0], &h); err != nil {
+		return nil, err
+	}
+	if err := decode(parts[1], &c); err != nil {
+		return nil, err
+	}
+	if c.Exp == nil || c.Domains == nil {
+		return nil, ErrMalformed
+	}
+	sig, err := b64.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	err = ErrUnknownKey
+	for _, k := range keys {
+		if k.alg != h.Alg || (h.Kid != "" && k.id != "" && h.Kid != k.id) {
+			continue
+		}
+		if err = ErrSignature; k.verify(signed, sig) {
+			err = nil
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l := &License{Customer: c.Sub, Domains: c.Domains, Expiry: unix(*c.Exp)}
+	if c.Iat != nil {
+		l.IssuedAt = unix(*c.Iat)
+	}
+	if float64(now.Unix())+float64(now.Nanosecond())/1e9 >= *c.Exp {
+		return l, ErrExpired
+	}
+	return l, nil
+}
+
+// decode decodes a part of a token into v.
+func decode(part string, v interface{}) error {
+	b, err := b64.DecodeString(part)
+	if err != nil || json.Unmarshal(b, v) != nil {
+		return ErrMalformed
+	}
+	return nil
+}
+
+// unix converts a Unix time in seconds, clamped to the times Go represents.
+func unix(sec float64) time.Time {
+	sec = math.Max(-1<<62, math.Min(sec, 1<<62))
+	s := math.Floor(sec)
+	return time.Unix(int64(s), int64((sec-s)*1e9))
+}
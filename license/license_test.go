@@ -0,0 +1,148 @@
+package license_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/woobleio/wooblizer/license"
+)
+
+var (
+	issued = time.Unix(1700000000, 0)
+	expiry = time.Unix(1900000000, 0)
+	now    = time.Unix(1800000000, 0)
+)
+
+func TestSignAndVerify(t *testing.T) {
+	for _, alg := range []license.Algorithm{license.EdDSA, license.HS256} {
+		k, err := license.GenerateKey(alg, "k1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := k.Sign(&license.License{Customer: "cus_42", Domains: []string{"example.com"}, Expiry: expiry, IssuedAt: issued})
+		if err != nil {
+			t.Fatalf("%s : Failed to sign, error %s", alg, err)
+		}
+
+		l, err := license.Verify(token, now, k.Public())
+		if err != nil {
+			t.Fatalf("%s : Failed to verify, error %s", alg, err)
+		}
+		if l.Customer != "cus_42" || !l.Expiry.Equal(expiry) || !l.IssuedAt.Equal(issued) || !l.Allows("EXAMPLE.com") || l.Allows("a.example.com") {
+			t.Errorf("%s : Unexpected license %+v", alg, l)
+		}
+		if _, err := license.Verify(token, expiry, k); err != license.ErrExpired {
+			t.Errorf("%s : Expected %s at expiry, got %v", alg, license.ErrExpired, err)
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	k, err := license.GenerateKey(license.EdDSA, "2024")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := k.Public()
+	if pub.CanSign() || !k.CanSign() {
+		t.Error("Only the private key should sign")
+	}
+	if _, err := pub.Sign(&license.License{Domains: []string{"example.com"}, Expiry: expiry}); !errors.Is(err, license.ErrKey) {
+		t.Errorf("Expected %s signing with a public key, got %v", license.ErrKey, err)
+	}
+
+	for _, key := range []*license.Key{k, pub} {
+		data, err := key.MarshalPEM()
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := license.ParseKey(data)
+		if err != nil {
+			t.Fatalf("Failed to parse\n%s\nerror %s", data, err)
+		}
+		if parsed.ID() != "2024" || parsed.CanSign() != key.CanSign() || string(parsed.VerifyingKey()) != string(k.VerifyingKey()) {
+			t.Errorf("Unexpected key parsed from\n%s", data)
+		}
+	}
+
+	if _, err := license.NewHMACKey("", make([]byte, license.MinSecretSize-1)); !errors.Is(err, license.ErrKey) {
+		t.Errorf("Expected %s for a short secret, got %v", license.ErrKey, err)
+	}
+	if _, err := license.GenerateKey("RS256", ""); !errors.Is(err, license.ErrKey) {
+		t.Errorf("Expected %s for an unknown algorithm, got %v", license.ErrKey, err)
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	old, _ := license.GenerateKey(license.EdDSA, "2023")
+	cur, _ := license.GenerateKey(license.EdDSA, "2024")
+	l := &license.License{Domains: []string{"example.com"}, Expiry: expiry}
+	keys := []*license.Key{old.Public(), cur.Public()}
+
+	for _, k := range []*license.Key{old, cur} {
+		token, _ := k.Sign(l)
+		if _, err := license.Verify(token, now, keys...); err != nil {
+			t.Errorf("Failed to verify a license of key %s, error %s", k.ID(), err)
+		}
+	}
+
+	retired, _ := license.GenerateKey(license.EdDSA, "2022")
+	token, _ := retired.Sign(l)
+	if _, err := license.Verify(token, now, keys...); err != license.ErrUnknownKey {
+		t.Errorf("Expected %s for a retired key, got %v", license.ErrUnknownKey, err)
+	}
+}
+
+// Vector is a license verified by Verify and by the runtime, shared with
+// the runtime tests of the library
+type Vector struct {
+	Name  string `json:"name"`
+	Alg   string `json:"alg"`
+	Key   string `json:"key"` // base64url verifying key
+	Token string `json:"token"`
+	Host  string `json:"host"`
+	Now   int64  `json:"now"`
+	Error string `json:"error"` // empty if valid
+}
+
+func TestVectors(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vectors []Vector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range vectors {
+		k, err := verifyingKey(v)
+		if err != nil {
+			t.Fatalf("%s : %s", v.Name, err)
+		}
+		l, err := license.Verify(v.Token, time.Unix(v.Now, 0), k)
+		if err == nil && !l.Allows(v.Host) {
+			err = license.ErrDomain
+		}
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != v.Error {
+			t.Errorf("%s : Expected %q, got %q", v.Name, v.Error, got)
+		}
+	}
+}
+
+func verifyingKey(v Vector) (*license.Key, error) {
+	b, err := base64.RawURLEncoding.DecodeString(v.Key)
+	if err != nil {
+		return nil, err
+	}
+	if license.Algorithm(v.Alg) == license.HS256 {
+		return license.NewHMACKey("", b)
+	}
+	return license.NewEd25519PublicKey("", b)
+}
@@ -0,0 +1,54 @@
+package wbzr
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+)
+
+// DefaultPolyfillURL is the Shadow DOM and custom elements polyfill loaded
+// by default.
+const DefaultPolyfillURL = "https://cdnjs.cloudflare.com/ajax/libs/webcomponentsjs/1.0.14/webcomponents-sd-ce.js"
+
+// Polyfill is how the runtime polyfills Shadow DOM and custom elements in
+// the browsers lacking them. The zero Polyfill loads DefaultPolyfillURL.
+type Polyfill struct {
+	URL       string // loaded by the runtime, DefaultPolyfillURL if empty
+	Integrity string // Subresource Integrity of URL, such as sha384-..., unchecked if empty
+	Inline    []byte // source run by the runtime instead of loading URL
+	Disabled  bool   // init fails in the browsers lacking Shadow DOM
+}
+
+// integrity is a Subresource Integrity metadata, hashes separated by spaces
+var integrity = regexp.MustCompile(`^(sha256|sha384|sha512)-[A-Za-z0-9+/]+={0,2}( +(sha256|sha384|sha512)-[A-Za-z0-9+/]+={0,2})*$`)
+
+// Src returns the URL the runtime loads the polyfill from.
+func (p Polyfill) Src() string {
+	if p.URL == "" {
+		return DefaultPolyfillURL
+	}
+	return p.URL
+}
+
+// check returns ErrPolyfill if the polyfill is misconfigured.
+func (p Polyfill) check() error {
+	if p.Integrity != "" && !integrity.MatchString(strings.TrimSpace(p.Integrity)) {
+		return fmt.Errorf("%w: bad integrity %q", ErrPolyfill, p.Integrity)
+	}
+	if p.Integrity != "" && (p.Inline != nil || p.Disabled) {
+		return fmt.Errorf("%w: integrity of a polyfill not loaded", ErrPolyfill)
+	}
+	return nil
+}
+
+// InlinePolyfill embeds the polyfill file at path in the library, the
+// runtime runs it instead of loading a URL.
+func (wb *Wbzr) InlinePolyfill(path string) error {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	wb.Polyfill = Polyfill{Inline: src}
+	return nil
+}
@@ -0,0 +1,227 @@
+package wbzr
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/woobleio/wooblizer/engine"
+)
+
+// SourceMap is a Source Map revision 3 of a wrapped library, it can be
+// encoded with encoding/json.
+type SourceMap struct {
+	Version        int      `json:"version"`
+	File           string   `json:"file,omitempty"`
+	Sources        []string `json:"sources"`
+	SourcesContent []string `json:"sourcesContent"`
+	Names          []string `json:"names"`
+	Mappings       string   `json:"mappings"`
+}
+
+// markedScript stands for a script while the template is executed, its
+// source is a marker replaced afterwards.
+type markedScript struct {
+	engine.Script
+	marker string
+}
+
+func (m markedScript) GetSource() string { return m.marker }
+
+// underlying returns the script a markedScript stands for, the optional
+// interfaces are asserted on it.
+func underlying(sc engine.Script) engine.Script {
+	if m, ok := sc.(markedScript); ok {
+		return m.Script
+	}
+	return sc
+}
+
+// segment relates a generated offset to an offset of an original source.
+type segment struct {
+	gen    int
+	source int
+	orig   int
+}
+
+// WrapWithSourceMap wraps like Wrap and builds the source map of the library.
+// Each creation maps to the source given to Inject, and to the prologue
+// generated by IncludeHTMLCSS. The library ends with a sourceMappingURL
+// comment pointing to url.
+func (wb *Wbzr) WrapWithSourceMap(url string) (*bytes.Buffer, *SourceMap, error) {
+	marked := *wb
+	marked.Scripts = make([]engine.Script, len(wb.Scripts))
+	for i, sc := range wb.Scripts {
+		marked.Scripts[i] = markedScript{sc, "\x00wbzr" + strconv.Itoa(i) + "\x00"}
+	}
+	tmp, err := marked.Wrap()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sm := &SourceMap{Version: 3, Sources: make([]string, 0), SourcesContent: make([]string, 0), Names: make([]string, 0)}
+	var segments []segment
+	var out bytes.Buffer
+	rest := tmp.String()
+	for i, sc := range wb.Scripts {
+		marker := marked.Scripts[i].GetSource()
+		at := strings.Index(rest, marker)
+		if at < 0 {
+			continue
+		}
+		out.WriteString(rest[:at])
+		rest = rest[at+len(marker):]
+
+		src := sc.GetSource()
+		base := out.Len()
+		out.WriteString(src)
+
+		var origins []engine.OriginalSource
+		var mappings []engine.Mapping
+		if mapper, ok := sc.(engine.SourceMapper); ok {
+			origins, mappings = mapper.Origins()
+		} else {
+			name := sc.GetName() + ".js"
+			origins = []engine.OriginalSource{{Name: name, Content: src}}
+			mappings = []engine.Mapping{{Start: 0, End: len(src), Source: name, Offset: 0}}
+		}
+		index := make(map[string]int)
+		for _, o := range origins {
+			index[o.Name] = len(sm.Sources)
+			sm.Sources = append(sm.Sources, o.Name)
+			sm.SourcesContent = append(sm.SourcesContent, o.Content)
+		}
+		for _, m := range mappings {
+			srcIdx, ok := index[m.Source]
+			if !ok {
+				continue
+			}
+			for _, pos := range tokenStarts(src, m.Start, m.End) {
+				segments = append(segments, segment{base + pos, srcIdx, m.Offset + pos - m.Start})
+			}
+		}
+	}
+	out.WriteString(rest)
+	out.WriteString("\n//# sourceMappingURL=" + url + "\n")
+
+	sm.Mappings = encodeMappings(out.String(), sm.SourcesContent, segments)
+
+	return &out, sm, nil
+}
+
+// tokenStarts returns the offsets of src in [start, end) where a word or a
+// punctuation starts, so that every token of a creation is mapped.
+func tokenStarts(src string, start int, end int) []int {
+	var starts []int
+	prevWord, prevSpace := false, true
+	for i := start; i < end; {
+		r, w := utf8.DecodeRuneInString(src[i:])
+		space := r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		word := isWordRune(r)
+		if i == start || (!space && (prevSpace || !word || !prevWord)) {
+			starts = append(starts, i)
+		}
+		prevWord, prevSpace = word, space
+		i += w
+	}
+	return starts
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '$' || r >= 0x80 || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// lineIndex locates offsets of a text in lines and UTF-16 columns, as source
+// maps count them.
+type lineIndex struct {
+	text   string
+	starts []int
+
+	// last located offset, offsets are mostly located in increasing order
+	lastLine   int
+	lastOffset int
+	lastCol    int
+}
+
+func newLineIndex(text string) *lineIndex {
+	starts := []int{0}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &lineIndex{text: text, starts: starts}
+}
+
+// locate returns the 0-based line and column of an offset.
+func (li *lineIndex) locate(offset int) (int, int) {
+	line := sort.Search(len(li.starts), func(i int) bool { return li.starts[i] > offset }) - 1
+	from, col := li.starts[line], 0
+	if line == li.lastLine && from <= li.lastOffset && li.lastOffset <= offset {
+		from, col = li.lastOffset, li.lastCol
+	}
+	for _, r := range li.text[from:offset] {
+		col += len(utf16.Encode([]rune{r}))
+	}
+	li.lastLine, li.lastOffset, li.lastCol = line, offset, col
+	return line, col
+}
+
+// encodeMappings encodes segments into the mappings field of a source map.
+func encodeMappings(gen string, contents []string, segments []segment) string {
+	sort.SliceStable(segments, func(i, j int) bool { return segments[i].gen < segments[j].gen })
+
+	genIdx := newLineIndex(gen)
+	origIdx := make([]*lineIndex, len(contents))
+	for i, c := range contents {
+		origIdx[i] = newLineIndex(c)
+	}
+
+	var b strings.Builder
+	line, prevCol, prevSrc, prevLine, prevOrigCol := 0, 0, 0, 0, 0
+	first := true
+	for _, s := range segments {
+		genLine, genCol := genIdx.locate(s.gen)
+		if s.orig > len(contents[s.source]) {
+			continue
+		}
+		origLine, origCol := origIdx[s.source].locate(s.orig)
+		if genLine > line {
+			b.WriteString(strings.Repeat(";", genLine-line))
+			line, prevCol = genLine, 0
+		} else if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		writeVLQ(&b, genCol-prevCol)
+		writeVLQ(&b, s.source-prevSrc)
+		writeVLQ(&b, origLine-prevLine)
+		writeVLQ(&b, origCol-prevOrigCol)
+		prevCol, prevSrc, prevLine, prevOrigCol = genCol, s.source, origLine, origCol
+	}
+	return b.String()
+}
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// writeVLQ writes a base64 variable-length quantity.
+func writeVLQ(b *strings.Builder, v int) {
+	u := v << 1
+	if v < 0 {
+		u = (-v << 1) | 1
+	}
+	for {
+		digit := u & 31
+		u >>= 5
+		if u > 0 {
+			digit |= 32
+		}
+		b.WriteByte(base64Chars[digit])
+		if u == 0 {
+			return
+		}
+	}
+}
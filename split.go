@@ -0,0 +1,121 @@
+package wbzr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/woobleio/wooblizer/engine/ecma"
+)
+
+// LoaderFile is the file name of the loader of a split library.
+const LoaderFile = "wooble.js"
+
+// Manifest lists the files of a split library.
+type Manifest struct {
+	Loader string                  `json:"loader"`
+	Chunks map[string]string       `json:"chunks"` // chunk file of each creation, by creation name
+	Files  map[string]ManifestFile `json:"files"`  // by file name
+}
+
+// ManifestFile is a file of a split library.
+type ManifestFile struct {
+	Integrity string `json:"integrity"` // SHA-384 of the content, as in Subresource Integrity
+	Size      int    `json:"size"`
+}
+
+// chunkRef is the chunk of a creation, as the loader fetches it.
+type chunkRef struct {
+	File      string
+	Integrity string
+}
+
+// chunkFormat registers the function returning a creation and its style sheet
+// in the page, under the file name of its chunk.
+const chunkFormat = "(window.__wbChunks || (window.__wbChunks = {}))[%q] = %s;\n"
+
+// WrapSplit wraps like Wrap but splits the library: a loader, LoaderFile, and
+// a chunk per creation which init fetches on demand, once. It returns the
+// files by name and their manifest. The chunks are fetched from base, the
+// directory of the loader if empty. Their names hold a hash of their content
+// so they can be cached forever, and the loader checks their integrity.
+func (wb *Wbzr) WrapSplit(base string) (map[string]*bytes.Buffer, *Manifest, error) {
+	return wb.wrapSplit(base, false)
+}
+
+// WrapSplitMinified wraps like WrapSplit and minifies every file like
+// WrapMinified.
+func (wb *Wbzr) WrapSplitMinified(base string) (map[string]*bytes.Buffer, *Manifest, error) {
+	return wb.wrapSplit(base, true)
+}
+
+func (wb *Wbzr) wrapSplit(base string, minify bool) (map[string]*bytes.Buffer, *Manifest, error) {
+	tmpl, _, err := wb.templates()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make(map[string]*bytes.Buffer, len(wb.Scripts)+1)
+	m := &Manifest{Loader: LoaderFile, Chunks: make(map[string]string), Files: make(map[string]ManifestFile)}
+	add := func(file string, src string) error {
+		if minify {
+			var err error
+			if src, err = ecma.Minify(src); err != nil {
+				return fmt.Errorf("%s: %s", file, err)
+			}
+		}
+		sum := sha512.Sum384([]byte(src))
+		files[file] = bytes.NewBufferString(src)
+		m.Files[file] = ManifestFile{"sha384-" + base64.StdEncoding.EncodeToString(sum[:]), len(src)}
+		return nil
+	}
+
+	b := &build{Wbzr: wb, Base: base, Chunks: make(map[string]*chunkRef, len(wb.Scripts))}
+	if base != "" && !strings.HasSuffix(base, "/") {
+		b.Base += "/"
+	}
+	for _, sc := range wb.Scripts {
+		var fn bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&fn, "chunk", sc); err != nil {
+			return nil, nil, err
+		}
+		// The name changes with the content, so a chunk is cached until
+		// the creation changes
+		sum := sha256.Sum256(fn.Bytes())
+		file := chunkFile(sc.GetName()) + "." + hex.EncodeToString(sum[:4]) + ".js"
+		if err := add(file, fmt.Sprintf(chunkFormat, file, fn.String())); err != nil {
+			return nil, nil, err
+		}
+		m.Chunks[sc.GetName()] = file
+		b.Chunks[sc.GetName()] = &chunkRef{file, m.Files[file].Integrity}
+	}
+
+	loader, err := wb.execute(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := add(LoaderFile, loader.String()); err != nil {
+		return nil, nil, err
+	}
+	return files, m, nil
+}
+
+// chunkFile returns the base name of the chunk of a creation, the characters
+// which are not safe in a URL are replaced.
+func chunkFile(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '-' || r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
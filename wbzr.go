@@ -4,49 +4,129 @@ package wbzr
 
 import (
 	"bytes"
+	"encoding/base64"
+	"fmt"
 	"io/ioutil"
 	"path"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
+	"unicode"
 
 	"github.com/woobleio/wooblizer/engine"
+	"github.com/woobleio/wooblizer/engine/doc"
+	"github.com/woobleio/wooblizer/engine/ecma"
+	"github.com/woobleio/wooblizer/license"
 )
 
-// ScriptLang are constants for implemented script languages.
-type ScriptLang int
+// ScriptLang is the name of a script language engine, registered with
+// engine.Register.
+type ScriptLang string
 
-// Supported engines
+// Built-in engines
 const (
-	JS ScriptLang = iota
+	// JS takes creations compiled to ES5 by Babel
+	JS ScriptLang = "js"
+	// JSClass takes native ES2015 classes, for browsers supporting them
+	JSClass ScriptLang = "js-class"
 )
 
+func init() {
+	engine.Register(string(JS), engine.Factory{
+		DecodeParams: engine.DecodeJSParams,
+		New:          engine.NewJSScript,
+		Runtime:      "js2015.js",
+		Templates:    []string{"wb.js", "chunk.js", "polyfill.js", "domain.js", "license.js"},
+		Asset:        apiAsset,
+	})
+	engine.Register(string(JSClass), engine.Factory{
+		DecodeParams: engine.DecodeJSParams,
+		New:          engine.NewJSClassScript,
+		Runtime:      "jsclass.js",
+		Templates:    []string{"wb.js", "chunk.js", "polyfill.js", "domain.js", "license.js"},
+		Asset:        apiAsset,
+	})
+}
+
+// apiAsset loads the templates of the built-in engines.
+func apiAsset(name string) ([]byte, error) {
+	return Asset(path.Join("apis", name))
+}
+
+// Format is the module format of a wrapped library.
+type Format int
+
+// Library formats
+const (
+	// Global defines Wb as a global function
+	Global Format = iota
+	// ESModule exports Wb as default, and each creation by name
+	ESModule
+	// CommonJS exports Wb and each creation by name
+	CommonJS
+	// UMD exports like CommonJS for AMD and CommonJS loaders, it defines the
+	// global Wb otherwise
+	UMD
+	// CustomElements defines Wb, and a custom element per creation named
+	// woobly-<name>. Its attributes are the parameters of the creation.
+	CustomElements
+)
+
+// formatTemplates are the templates of the formats, they include the runtime
+// of the engine.
+var formatTemplates = map[Format]string{
+	ESModule: "esm.js",
+	CommonJS: "cjs.js",
+	UMD:      "umd.js",
+
+	CustomElements: "elements.js",
+}
+
 // Wbzr is the wooblizer system
 type Wbzr struct {
 	DomainsSec []string
 	Scripts    []engine.Script
+	Format     Format
+
+	// AllowLocalhost also serves a library secured by Secure on localhost,
+	// its subdomains and the loopback addresses, for development.
+	AllowLocalhost bool
+
+	// License is verified by the runtime before it serves the creations, nil
+	// if the library is not licensed. It is set by SecureWithLicense.
+	License *License
+
+	// Policy sanitizes the markup given to IncludeHTMLCSS, for the creations
+	// injected once it is set. nil keeps all the markup.
+	Policy *doc.Policy
 
-	lang     ScriptLang
-	apiPath  string
-	filename string
+	// Polyfill is how the runtime polyfills Shadow DOM and custom elements
+	Polyfill Polyfill
+
+	lang   ScriptLang
+	engine engine.Factory
 }
 
-// New takes a script language which is used to inject and output a file.
-func New(sl ScriptLang) *Wbzr {
-	apiPath := "apis"
-	var filename string
-	switch sl {
-	case JS:
-		filename = "js2015.js"
-	default:
-		panic("Language not supported")
+// New takes a script language which is used to inject and output a file, it
+// returns ErrUnknownLang if no engine is registered for it.
+func New(sl ScriptLang) (*Wbzr, error) {
+	f, ok := engine.Lookup(string(sl))
+	if !ok {
+		return nil, ErrUnknownLang
 	}
 
 	return &Wbzr{
 		nil,
 		make([]engine.Script, 0),
+		Global,
+		false,
+		nil,
+		nil,
+		Polyfill{},
 		sl,
-		apiPath,
-		filename,
-	}
+		f,
+	}, nil
 }
 
 // Get returns an injected source.
@@ -61,76 +141,358 @@ func (wb *Wbzr) Get(name string) (engine.Script, error) {
 
 // Inject injects a source code to be wooblized. It takes a name which must be
 // unique. Src can be empty, it'll create a default object
-func (wb *Wbzr) Inject(src string, name string, params []interface{}) (engine.Script, []error) {
-	errs := make([]error, 0)
+func (wb *Wbzr) Inject(src string, name string, params []interface{}) (engine.Script, []*engine.Diagnostic) {
+	diags := make([]*engine.Diagnostic, 0)
 	if _, err := wb.Get(name); err == nil {
-		errs = append(errs, err)
-		return nil, errs
+		d := engine.NewDiagnostic(engine.CodeUniqueName, ErrUniqueName)
+		d.Name = name
+		return nil, append(diags, d)
 	}
-	var sc engine.Script
-
-	switch wb.lang {
-	case JS:
-		var jsParams = make([]engine.JSParam, len(params))
-		for i, p := range params {
-			jsParams[i] = p.(engine.JSParam)
+	if wb.engine.DecodeParams != nil {
+		var err error
+		if params, err = wb.engine.DecodeParams(params); err != nil {
+			d := engine.NewDiagnostic(engine.CodeParams, err)
+			d.Name = name
+			return nil, append(diags, d)
 		}
-		sc, errs = engine.NewJS(name, src, jsParams)
 	}
 
-	if len(errs) > 0 {
-		return sc, errs
+	sc, diags := wb.engine.New(name, src, params)
+	if engine.HasErrors(diags) {
+		return sc, diags
+	}
+	if s, ok := sc.(engine.Sanitizer); ok {
+		s.SetPolicy(wb.Policy)
 	}
 
 	wb.Scripts = append(wb.Scripts, sc)
 
-	return sc, errs
+	return sc, diags
 }
 
 // InjectFile injects a source from a file.
-func (wb *Wbzr) InjectFile(path string, name string, params []interface{}) (engine.Script, []error) {
-	errs := make([]error, 0)
+func (wb *Wbzr) InjectFile(path string, name string, params []interface{}) (engine.Script, []*engine.Diagnostic) {
 	c, err := ioutil.ReadFile(path)
 	if err != nil {
-		errs = append(errs, err)
-		return nil, errs
+		d := engine.NewDiagnostic(engine.CodeIO, err)
+		d.Name = name
+		return nil, []*engine.Diagnostic{d}
 	}
 
 	return wb.Inject(string(c[:]), name, params)
 }
 
-// Secure set some domains to protect the script and make it works only for specific domains
-func (wb *Wbzr) Secure(domains ...string) {
+// Secure set some domains to protect the script and make it works only for specific domains.
+// A domain is a host name, *.host for its subdomains, an IP address or a CIDR range (IPv6
+// in brackets), followed by an optional :port. The domains are left unchanged if one of
+// them is invalid.
+func (wb *Wbzr) Secure(domains ...string) error {
+	for _, d := range domains {
+		if _, err := parseDomain(d); err != nil {
+			return err
+		}
+	}
 	wb.DomainsSec = domains
+	return nil
+}
+
+// License is a signed license embedded in a library.
+type License struct {
+	Token string
+	Key   *license.Key // verifies the token, a public key for EdDSA
+}
+
+// Alg returns the algorithm the runtime verifies the token with.
+func (l *License) Alg() string { return string(l.Key.Algorithm()) }
+
+// KeyData returns the verifying key embedded in the library, in base64url.
+func (l *License) KeyData() string {
+	return base64.RawURLEncoding.EncodeToString(l.Key.VerifyingKey())
+}
+
+// SecureWithLicense secures the library with a license signed by key: the
+// runtime verifies the signature of the token, its expiry and its domains
+// before serving the creations. The token must be valid now. Only the public
+// part of an Ed25519 key is embedded, an HMAC secret is embedded as is.
+func (wb *Wbzr) SecureWithLicense(token string, key *license.Key) error {
+	key = key.Public()
+	if _, err := license.Verify(token, time.Now(), key); err != nil {
+		return err
+	}
+	wb.License = &License{token, key}
+	return nil
 }
 
 // SecureAndWrap wrap all scripts in the wooblizer and secure it with domains
 func (wb *Wbzr) SecureAndWrap(domains ...string) (*bytes.Buffer, error) {
-	wb.Secure(domains...)
+	if err := wb.Secure(domains...); err != nil {
+		return nil, err
+	}
 	return wb.Wrap()
 }
 
 // Wrap packages some creations (all the creations injected in the Wbzr)
-// and build a file which contains the wooble library.
+// and build a file which contains the wooble library, in the format of the
+// Wbzr.
 func (wb *Wbzr) Wrap() (*bytes.Buffer, error) {
+	return wb.execute(&build{Wbzr: wb})
+}
+
+// build is the data of the runtime templates. Chunks is nil unless the
+// library is split.
+type build struct {
+	*Wbzr
+	Base   string
+	Chunks map[string]*chunkRef
+}
+
+// execute executes the runtime templates, in the format of the Wbzr.
+func (wb *Wbzr) execute(b *build) (*bytes.Buffer, error) {
+	tmpl, name, err := wb.templates()
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&out, name, b); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// templates parses the runtime templates, name is the template of the format.
+func (wb *Wbzr) templates() (*template.Template, string, error) {
+	if err := wb.Polyfill.check(); err != nil {
+		return nil, "", err
+	}
+	if err := wb.checkExports(); err != nil {
+		return nil, "", err
+	}
+	if err := wb.checkElements(); err != nil {
+		return nil, "", err
+	}
 	fns := template.FuncMap{
 		"plus1": func(x int) int {
 			return x + 1
 		},
+		"ident":   jsIdent,
+		"tag":     elementName,
+		"attr":    attrName,
+		"style":   scriptStyle,
+		"schema":  scriptSchema,
+		"domains": scriptDomains,
+		"helpers": scriptHelpers,
 	}
 
-	d, err := Asset(path.Join(wb.apiPath, wb.filename))
+	name := "runtime"
+	tmpl := template.New(name).Funcs(fns)
+	names := append([]string{wb.engine.Runtime}, wb.engine.Templates...)
+	if f, ok := formatTemplates[wb.Format]; ok {
+		name = f
+		names = append(names, f)
+	}
+	for i, n := range names {
+		d, err := wb.engine.Asset(n)
+		if err != nil {
+			return nil, "", err
+		}
+		t := tmpl
+		if i > 0 {
+			t = tmpl.New(n)
+		}
+		if _, err := t.Parse(string(d)); err != nil {
+			return nil, "", err
+		}
+	}
+	return tmpl, name, nil
+}
+
+// checkExports returns ErrExportName if a creation can't be exported by its
+// name in the format : the name is Wb, or in an ES module two names are the
+// same identifier or one is the name of a helper.
+func (wb *Wbzr) checkExports() error {
+	if wb.Format != ESModule && wb.Format != CommonJS && wb.Format != UMD {
+		return nil
+	}
+	taken := map[string]string{"Wb": "the runtime"}
+	if wb.Format == ESModule {
+		for _, h := range engine.Helpers {
+			taken[h.Name] = "a helper"
+		}
+	}
+	for _, sc := range wb.Scripts {
+		name := sc.GetName()
+		if wb.Format == ESModule {
+			name = jsIdent(name)
+		}
+		if by, ok := taken[name]; ok {
+			return fmt.Errorf("%w: %q is exported as %s, like %s", ErrExportName, sc.GetName(), name, by)
+		}
+		taken[name] = strconv.Quote(sc.GetName())
+	}
+	return nil
+}
+
+// checkElements returns ErrElementName if two creations are defined as the
+// same custom element, the second definition would be skipped.
+func (wb *Wbzr) checkElements() error {
+	if wb.Format != CustomElements {
+		return nil
+	}
+	taken := make(map[string]string, len(wb.Scripts))
+	for _, sc := range wb.Scripts {
+		tag := elementName(sc.GetName())
+		if other, ok := taken[tag]; ok {
+			return fmt.Errorf("%w: %q and %q are both %s", ErrElementName, other, sc.GetName(), tag)
+		}
+		taken[tag] = sc.GetName()
+	}
+	return nil
+}
+
+// strictReserved are the reserved words of the strict mode code, which are
+// not keywords.
+var strictReserved = map[string]bool{
+	"arguments": true, "await": true, "eval": true, "implements": true,
+	"interface": true, "let": true, "package": true, "private": true,
+	"protected": true, "public": true, "static": true, "yield": true,
+}
+
+// jsIdent turns a creation name into a JavaScript identifier, the invalid
+// characters are dropped and the following letter upper cased, the reserved
+// words are prefixed with _.
+// ex : my-creation => myCreation
+func jsIdent(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_' || r == '$' || unicode.IsLetter(r) || (unicode.IsDigit(r) && b.Len() > 0):
+			if upper {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			upper = false
+		case unicode.IsDigit(r):
+			b.WriteRune('_')
+			b.WriteRune(r)
+		default:
+			upper = b.Len() > 0
+		}
+	}
+	ident := b.String()
+	if ident == "" || ecma.IsKeyword(ident) || strictReserved[ident] {
+		ident = "_" + ident
+	}
+	return ident
+}
+
+// elementName returns the custom element name of a creation, it is prefixed
+// so it has the required hyphen and the invalid characters are replaced.
+// ex : My_Creation => woobly-my_creation
+func elementName(name string) string {
+	var b strings.Builder
+	b.WriteString("woobly-")
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '-' || r == '.' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// scriptSchema returns the schema of the typed parameters of a script as a
+// JavaScript object, or "" if it has none.
+func scriptSchema(sc engine.Script) (string, error) {
+	if s, ok := underlying(sc).(engine.Schemer); ok {
+		return s.GetSchema()
+	}
+	return "", nil
+}
+
+// scriptHelpers returns the definitions of the helpers used by a script, or
+// by a slice of scripts, each helper once.
+func scriptHelpers(scripts interface{}) string {
+	var list []engine.Script
+	switch x := scripts.(type) {
+	case engine.Script:
+		list = []engine.Script{x}
+	case []engine.Script:
+		list = x
+	}
+	used := make(map[string]bool)
+	for _, sc := range list {
+		if u, ok := underlying(sc).(engine.HelperUser); ok {
+			for _, name := range u.UsedHelpers() {
+				used[name] = true
+			}
+		}
+	}
+	var b strings.Builder
+	for _, h := range engine.Helpers {
+		if used[h.Name] {
+			b.WriteString(h.Source + "\n\n")
+		}
+	}
+	return b.String()
+}
+
+// scriptStyle returns the style sheet of a script as a JavaScript string, or
+// "" if it has none.
+func scriptStyle(sc engine.Script) string {
+	if st, ok := underlying(sc).(engine.Styler); ok {
+		return st.GetStyle()
+	}
+	return ""
+}
+
+// attrName returns the HTML attribute of a parameter, HTML attributes are
+// case insensitive so upper case letters start a new word.
+// ex : bgColor => bg-color
+func attrName(field string) string {
+	var b strings.Builder
+	for _, r := range field {
+		switch {
+		case unicode.IsUpper(r):
+			if b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
+
+// SizeReport is the weight in bytes of a library before and after its
+// minification.
+type SizeReport struct {
+	Before int
+	After  int
+}
+
+// WrapMinified wraps like Wrap and minifies the library: white spaces and
+// comments are stripped and local identifiers are shortened, in the runtime
+// and in every creation.
+func (wb *Wbzr) WrapMinified() (*bytes.Buffer, SizeReport, error) {
+	out, err := wb.Wrap()
 	if err != nil {
-		return nil, err
+		return nil, SizeReport{}, err
 	}
-	tmpl := template.Must(template.New(wb.filename).Funcs(fns).Parse(string(d)))
 
-	var out bytes.Buffer
-	if err := tmpl.Execute(&out, wb); err != nil {
-		return nil, err
+	min, err := ecma.Minify(out.String())
+	if err != nil {
+		return nil, SizeReport{}, err
 	}
 
-	return &out, nil
+	return bytes.NewBufferString(min), SizeReport{out.Len(), len(min)}, nil
 }
 
 // WooblyJS is a Wooble base code for creation
@@ -1,23 +1,40 @@
 package wbzr_test
 
 import (
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/woobleio/wooblizer"
 	"github.com/woobleio/wooblizer/engine"
+	"github.com/woobleio/wooblizer/engine/doc"
+	"github.com/woobleio/wooblizer/license"
 )
 
 func TestInject(t *testing.T) {
-	wb := wbzr.New(wbzr.JS)
-	if _, errs := wb.Inject("var Woobly=function Woobly(){};", "foo", make([]interface{}, 0)); len(errs) == 0 || (len(errs) > 0 && errs[0] != engine.ErrNoDocInit) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := wb.Inject("var Woobly=function Woobly(){};", "foo", make([]interface{}, 0)); len(errs) == 0 || (len(errs) > 0 && !errors.Is(errs[0], engine.ErrNoDocInit)) {
 		t.Error("Inject 1 : Should trigger an error => No document initializer")
 	}
 
-	if _, errs := wb.Inject("var Foobar = function(){function Foobar(){this.document=document}}", "bar", nil); len(errs) == 0 || (len(errs) > 0 && errs[0] != engine.ErrNoClassFound) {
+	if _, errs := wb.Inject("var Foobar = function(){function Foobar(){this.document=document}}", "bar", nil); len(errs) == 0 || (len(errs) > 0 && !errors.Is(errs[0], engine.ErrNoClassFound)) {
 		t.Error("Inject 2 : Should tigger an error => No class found")
 	}
 
-	if _, errs := wb.Inject("var Woobly = function(){}", "foobar", nil); len(errs) == 0 || (len(errs) > 0 && errs[0] != engine.ErrNoConstructor) {
+	if _, errs := wb.Inject("var Woobly = function(){}", "foobar", nil); len(errs) == 0 || (len(errs) > 0 && !errors.Is(errs[0], engine.ErrNoConstructor)) {
 		t.Error("Inject 3 : Should tigger an error => No constructor found")
 	}
 
@@ -25,10 +42,46 @@ func TestInject(t *testing.T) {
 	if _, err := wb.Inject("otherObj={}", "foo", nil); err == nil {
 		t.Error("Inject 4 : Should trigger an error => Unique alias only")
 	}
+
+	valid := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; };"
+	if _, errs := wb.Inject(valid, "baz", nil); len(errs) > 0 {
+		t.Errorf("Inject 5 : Valid creation rejected, errors %s", errs)
+	}
+	if _, errs := wb.Inject(valid, "baz", nil); len(errs) == 0 || !errors.Is(errs[0], wbzr.ErrUniqueName) {
+		t.Error("Inject 6 : Should trigger an error => Unique alias only")
+	}
+}
+
+func TestInjectDiagnostics(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, errs := wb.Inject("var Woobly = function Woobly(params) {\n\tconsole.log(params);\n};", "diag", nil)
+	if len(errs) != 1 {
+		t.Fatalf("Inject : Expected one diagnostic, got %v", errs)
+	}
+	d := errs[0]
+	if d.Code != engine.CodeNoDocInit || d.Severity != engine.SeverityError {
+		t.Errorf("Inject : Unexpected diagnostic %s", d)
+	}
+	if d.Range.Start.Line != 1 || d.Range.Start.Column != 38 || d.Range.End.Line != 3 || d.Range.End.Column != 2 {
+		t.Errorf("Inject : Unexpected range %+v", d.Range)
+	}
+	if d.Fix == nil || d.Fix.Range.Start.Offset != 38 || d.Fix.NewText != "this.document = document.body.shadowRoot;" {
+		t.Errorf("Inject : Unexpected fix %+v", d.Fix)
+	}
+	if d.Error() != "diag:1:38: error: the constructor must initialize this.document with document.body.shadowRoot [no-doc-init]" {
+		t.Errorf("Inject : Unexpected message %s", d)
+	}
 }
 
 func TestSecureAndWrap(t *testing.T) {
-	wb := wbzr.New(wbzr.JS)
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	var params = make([]interface{}, 2)
 	params[0] = engine.JSParam{Field: "par1", Value: "'value1'"}
@@ -75,4 +128,1010 @@ func TestSecureAndWrap(t *testing.T) {
 	}
 
 	t.Log(bf.String())
+
+	min, size, errMin := wb.WrapMinified()
+	if errMin != nil {
+		t.Fatalf("Failed to wrap minified, error %s", errMin)
+	}
+	if size.Before != bf.Len() || size.After != min.Len() || size.After >= size.Before {
+		t.Errorf("Unexpected size report %+v", size)
+	}
+	if strings.Contains(min.String(), "\n") {
+		t.Error("Minified library should not contain new lines")
+	}
+}
+
+func TestWrapWithSourceMap(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := "var Woobly = function () {\n  function Woobly(params) {\n    this.document = document.body.shadowRoot;\n    throw new Error(params);\n  }\n  return Woobly;\n}();"
+	script, errs := wb.Inject(src, "mapped", nil)
+	if len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+	if err := script.IncludeHTMLCSS("<p>hello</p>", "p { color: red; }"); err != nil {
+		t.Fatalf("Failed to include HTML, error %s", err)
+	}
+
+	// A Babel creation with a typed parameter
+	babel := `function _classCallCheck(instance, Constructor) { if (!(instance instanceof Constructor)) { throw new TypeError("Cannot call a class as a function"); } }
+
+var Woobly = function Woobly(target, params) {
+  _classCallCheck(this, Woobly);
+  this.document = document.body.shadowRoot;
+  this.stars = params.stars;
+};`
+	max := 5.0
+	if _, errs := wb.Inject(babel, "babel", []interface{}{
+		engine.JSParam{Field: "stars", Type: engine.ParamNumber, Default: 3, Rules: &engine.ParamRules{Max: &max}},
+	}); len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+
+	bf, sm, err := wb.WrapWithSourceMap("lib.js.map")
+	if err != nil {
+		t.Fatalf("Failed to wrap, error %s", err)
+	}
+	lib := bf.String()
+	if !strings.Contains(lib, "function _classCallCheck(") || !strings.Contains(lib, `'p{color:red}'`) || !strings.Contains(lib, `"max":5`) {
+		t.Errorf("The library should have the helpers, the style sheets and the schemas of Wrap\n%s", lib)
+	}
+	if !strings.HasSuffix(bf.String(), "\n//# sourceMappingURL=lib.js.map\n") {
+		t.Error("The library should end with the source map URL")
+	}
+	if len(sm.Sources) != 3 || sm.Sources[0] != "mapped.js" || sm.Sources[1] != "mapped.prologue.js" || sm.Sources[2] != "babel.js" || sm.SourcesContent[0] != src {
+		t.Fatalf("Unexpected sources %v", sm.Sources)
+	}
+
+	// throw must map to the line 4, column 4 of the injected source, and
+	// createElement to the prologue
+	lines := strings.Split(bf.String(), "\n")
+	segments := decodeMappings(sm.Mappings)
+	for _, test := range []struct {
+		token             string
+		source, line, col int
+	}{
+		{"throw new Error", 0, 3, 4},
+		{"document.createElement('p')", 1, 0, 53},
+	} {
+		found := false
+		for l, text := range lines {
+			col := strings.Index(text, test.token)
+			if col < 0 {
+				continue
+			}
+			for _, seg := range segments[l] {
+				if seg[0] == col {
+					found = true
+					if seg[1] != test.source || seg[2] != test.line || seg[3] != test.col {
+						t.Errorf("%s : Unexpected mapping %v", test.token, seg)
+					}
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s : Not mapped", test.token)
+		}
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	args, _ := json.Marshal(map[string]interface{}{"wooble.js": map[string]string{"src": lib}})
+	page := splitPage + `
+Wb('babel').init('#t', {stars: 9}).then(function (cs) {
+  console.log(cs[0].stars);
+});`
+	out, err := exec.Command(node, "-e", page, string(args)).CombinedOutput()
+	if expected := "Wooble error : parameter stars of babel: expected at most 5\n3\n"; err != nil || string(out) != expected {
+		t.Errorf("The library should run, expected\n%s\ngot\n%s, error %v", expected, out, err)
+	}
+}
+
+// decodeMappings decodes the absolute segments of each generated line.
+func decodeMappings(mappings string) [][][4]int {
+	const chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	var lines [][][4]int
+	var state [4]int
+	for _, line := range strings.Split(mappings, ";") {
+		state[0] = 0
+		var segs [][4]int
+		for _, field := range strings.Split(line, ",") {
+			if field == "" {
+				continue
+			}
+			var values []int
+			v, shift := 0, uint(0)
+			for _, c := range field {
+				d := strings.IndexRune(chars, c)
+				v += (d & 31) << shift
+				shift += 5
+				if d&32 == 0 {
+					if v&1 == 1 {
+						values = append(values, -(v >> 1))
+					} else {
+						values = append(values, v>>1)
+					}
+					v, shift = 0, 0
+				}
+			}
+			for i := range values {
+				state[i] += values[i]
+			}
+			segs = append(segs, state)
+		}
+		lines = append(lines, segs)
+	}
+	return lines
+}
+
+func TestWrapFormats(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", "my-creation", nil); len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+
+	for _, test := range []struct {
+		format   wbzr.Format
+		expected string
+	}{
+		{wbzr.Global, "function Wb(id) {"},
+		{wbzr.ESModule, "export default Wb;\nexport { Wb };\n\nexport var myCreation = Wb(\"my-creation\");"},
+		{wbzr.CommonJS, "exports.Wb = Wb;\n\nexports[\"my-creation\"] = Wb(\"my-creation\");"},
+		{wbzr.UMD, "return {\n    Wb: Wb,\n    \"my-creation\": Wb(\"my-creation\")\n  };\n}));"},
+	} {
+		wb.Format = test.format
+		bf, err := wb.Wrap()
+		if err != nil {
+			t.Fatalf("Format %d : Failed to wrap, error %s", test.format, err)
+		}
+		if !strings.Contains(bf.String(), test.expected) {
+			t.Errorf("Format %d : Unexpected library %s", test.format, bf.String())
+		}
+		if _, _, err := wb.WrapMinified(); err != nil {
+			t.Errorf("Format %d : Failed to minify, error %s", test.format, err)
+		}
+	}
+
+	// The names are strings, whatever their characters
+	name := `x"+alert(1)+"</script>`
+	if _, errs := wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", name, nil); len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+	for _, format := range []wbzr.Format{wbzr.Global, wbzr.ESModule, wbzr.CommonJS, wbzr.UMD} {
+		wb.Format = format
+		if _, _, err := wb.WrapMinified(); err != nil {
+			t.Errorf("Format %d : Failed to minify a quoted name, error %s", format, err)
+		}
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	wb.Format = wbzr.CommonJS
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := "var exports = {};\n" + bf.String() + "\nconsole.log(JSON.stringify(Object.keys(exports)));"
+	out, err := exec.Command(node, "-e", script).CombinedOutput()
+	if expected := `["Wb","my-creation","x\"+alert(1)+\"</script>"]` + "\n"; err != nil || string(out) != expected {
+		t.Errorf("Expected the exports %s, got %s, error %v", expected, out, err)
+	}
+}
+
+func TestWrapExportNames(t *testing.T) {
+	src := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; };"
+	for _, test := range []struct {
+		names   []string
+		invalid []wbzr.Format
+	}{
+		{[]string{"my-creation", "myCreation"}, []wbzr.Format{wbzr.ESModule}},
+		{[]string{"Wb"}, []wbzr.Format{wbzr.ESModule, wbzr.CommonJS, wbzr.UMD}},
+		{[]string{"_extends"}, []wbzr.Format{wbzr.ESModule}},
+		{[]string{"await", "arguments", "default"}, nil},
+	} {
+		wb, err := wbzr.New(wbzr.JS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range test.names {
+			if _, errs := wb.Inject(src, name, nil); len(errs) > 0 {
+				t.Fatalf("Failed to inject %q, errors %s", name, errs)
+			}
+		}
+		for _, format := range []wbzr.Format{wbzr.Global, wbzr.ESModule, wbzr.CommonJS, wbzr.UMD} {
+			invalid := false
+			for _, f := range test.invalid {
+				invalid = invalid || f == format
+			}
+			wb.Format = format
+			_, err := wb.Wrap()
+			if invalid && !errors.Is(err, wbzr.ErrExportName) {
+				t.Errorf("Format %d : Expected %s for %q, got %v", format, wbzr.ErrExportName, test.names, err)
+			} else if !invalid && err != nil {
+				t.Errorf("Format %d : Failed to wrap %q, error %s", format, test.names, err)
+			}
+		}
+	}
+
+	// The reserved words of the strict mode are renamed
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb.Format = wbzr.ESModule
+	for _, name := range []string{"await", "arguments", "yield"} {
+		if _, errs := wb.Inject(src, name, nil); len(errs) > 0 {
+			t.Fatalf("Failed to inject %q, errors %s", name, errs)
+		}
+	}
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(bf.String(), "export var _await = Wb(\"await\");\nexport var _arguments = Wb(\"arguments\");") {
+		t.Errorf("Unexpected exports %s", bf.String())
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	path := filepath.Join(t.TempDir(), "lib.mjs")
+	if err := ioutil.WriteFile(path, bf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command(node, "--check", path).CombinedOutput(); err != nil {
+		t.Errorf("The module does not parse, error %v %s", err, out)
+	}
+}
+
+func TestEngineRegistry(t *testing.T) {
+	if _, err := wbzr.New("cobol"); !errors.Is(err, wbzr.ErrUnknownLang) {
+		t.Errorf("New : Expected ErrUnknownLang, got %v", err)
+	}
+
+	engine.Register("test", engine.Factory{
+		New:     engine.NewJSScript,
+		Runtime: "runtime.js",
+		Asset: func(name string) ([]byte, error) {
+			return []byte(`{{range .Scripts}}{{.GetName}};{{end}}`), nil
+		},
+	})
+	wb, err := wbzr.New("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; };"
+	if _, errs := wb.Inject(valid, "foo", nil); len(errs) > 0 {
+		t.Fatalf("Inject : Valid creation rejected, errors %s", errs)
+	}
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bf.String() != "foo;" {
+		t.Errorf("Wrap : Expected the runtime of the engine, got %q", bf.String())
+	}
+}
+
+func TestInjectParams(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; };"
+	params := []interface{}{
+		engine.JSParam{Field: "a", Value: "1"},
+		map[string]interface{}{"field": "b", "value": "2"},
+	}
+	sc, errs := wb.Inject(valid, "foo", params)
+	if len(errs) > 0 {
+		t.Fatalf("Inject : Valid parameters rejected, errors %s", errs)
+	}
+	if got := sc.GetParams(); len(got) != 2 || got[1] != (engine.JSParam{Field: "b", Value: "2"}) {
+		t.Errorf("Inject : Unexpected parameters %v", got)
+	}
+
+	if _, errs := wb.Inject(valid, "bar", []interface{}{42}); len(errs) != 1 || errs[0].Code != engine.CodeParams {
+		t.Errorf("Inject : Expected a params diagnostic, got %v", errs)
+	}
+
+	// Typed parameters decoded from JSON
+	var typed []interface{}
+	if err := json.Unmarshal([]byte(`[
+		{"field": "size", "type": "number", "default": 2, "min": 1, "max": 3},
+		{"field": "align", "type": "enum", "enum": ["left", "right"], "required": true}
+	]`), &typed); err != nil {
+		t.Fatal(err)
+	}
+	sc, errs = wb.Inject(valid, "typed", typed)
+	if len(errs) > 0 {
+		t.Fatalf("Inject : Valid typed parameters rejected, errors %s", errs)
+	}
+	if p := sc.GetParams()[0].(engine.JSParam); p.Type != engine.ParamNumber || p.Default != 2.0 || *p.Rules.Min != 1 || *p.Rules.Max != 3 {
+		t.Errorf("Inject : Unexpected typed parameter %+v", p)
+	}
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(bf.String(), `"typed":{"align":{"type":"enum","required":true,"enum":["left","right"]},"size":{"type":"number","min":1,"max":3}}`) {
+		t.Errorf("Wrap : Unexpected schemas %s", bf.String())
+	}
+	if _, errs := wb.Inject(valid, "baz", []interface{}{map[string]interface{}{"field": "a", "type": "number", "min": "1"}}); len(errs) != 1 ||
+		errs[0].Code != engine.CodeParams || errs[0].Message != "parameter 0: min must be a number" {
+		t.Errorf("Inject : Expected a params diagnostic, got %v", errs)
+	}
+}
+
+func TestInjectPlaceholders(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb.Policy = doc.NewPolicy(doc.Strict)
+	valid := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; };"
+	sc, errs := wb.Inject(valid, "card", []interface{}{engine.JSParam{Field: "title", Value: "'Hi'"}})
+	if len(errs) > 0 {
+		t.Fatalf("Inject : Valid creation rejected, errors %s", errs)
+	}
+	if err := sc.IncludeHTMLCSS("<!-- {{draft}} --><script>{{code}}</script><h1>{{title}}</h1>", ""); err != nil {
+		t.Errorf("IncludeHTMLCSS : Unexpected error %v", err)
+	}
+	err = sc.IncludeHTMLCSS("<h1>{{title}}</h1>\n<p>{{subtitle}}</p>", "")
+	if d, ok := err.(*engine.Diagnostic); !ok || !errors.Is(d, engine.ErrUnknownPlaceholder) || d.Name != "card" || d.Range.Start.Line != 2 {
+		t.Errorf("IncludeHTMLCSS : Expected an unknown placeholder diagnostic, got %+v", err)
+	}
+}
+
+func TestWrapJSClass(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JSClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc, errs := wb.Inject(wbzr.WooblyJS, "starter", nil)
+	if len(errs) > 0 {
+		t.Fatalf("Inject : The starter class is rejected, errors %s", errs)
+	}
+	if err := sc.IncludeHTMLCSS("<p>hello</p>", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(bf.String(), `"starter":class Woobly {`) {
+		t.Errorf("Wrap : The class is not in the library %s", bf.String())
+	}
+	if strings.Contains(bf.String(), "_classCallCheck") {
+		t.Error("Wrap : The native class runtime should not define the Babel helpers")
+	}
+	if _, _, err := wb.WrapMinified(); err != nil {
+		t.Errorf("WrapMinified : Failed to minify, error %s", err)
+	}
+}
+
+func TestWrapStatements(t *testing.T) {
+	for _, test := range []struct {
+		engine wbzr.ScriptLang
+		src    string
+	}{
+		{wbzr.JS, "function util(a) { return a; }; var CONF = { a: 1 }; var Woobly = function Woobly() { this.document = document.body.shadowRoot; this.a = util(CONF.a); };"},
+		{wbzr.JSClass, "function util(a) { return a; }\nvar CONF = { a: 1 };\nclass Woobly { constructor() { this.document = document.body.shadowRoot; this.a = util(CONF.a); } }"},
+	} {
+		wb, err := wbzr.New(test.engine)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, errs := wb.Inject(test.src, "util", nil); len(errs) > 0 {
+			t.Fatalf("%s : Failed to inject, errors %s", test.engine, errs)
+		}
+		bf, _, err := wb.WrapMinified()
+		if err != nil {
+			t.Fatalf("%s : Failed to wrap, error %s", test.engine, err)
+		}
+		node, err := exec.LookPath("node")
+		if err != nil {
+			t.Skip("node not found")
+		}
+		args, _ := json.Marshal(map[string]interface{}{"wooble.js": map[string]string{"src": bf.String()}})
+		script := splitPage + `
+Wb('util').init('#t').then(function (cs) { console.log(cs[0].a); }, function (e) { console.log(e.message); });`
+		out, err := exec.Command(node, "-e", script, string(args)).CombinedOutput()
+		if err != nil || string(out) != "1\n" {
+			t.Errorf("%s : Expected the statements of the source to run, got %s, error %v", test.engine, out, err)
+		}
+	}
+}
+
+func TestWrapStyleSheets(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; };"
+	styled, _ := wb.Inject(src, "styled", nil)
+	plain, _ := wb.Inject(src, "plain", nil)
+	if err := styled.IncludeHTMLCSS("<p>hello</p>", "p { color: red; }"); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.IncludeHTMLCSS("<p>hello</p>", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The style sheet is given once to the runtime, not to each instance
+	if strings.Count(bf.String(), "p{color:red}") != 1 || !strings.Contains(bf.String(), `"styled":'p{color:red}'`) {
+		t.Errorf("Wrap : Unexpected style sheets %s", bf.String())
+	}
+	if strings.Contains(bf.String(), `"plain":'`) {
+		t.Error("Wrap : A creation without CSS should have no style sheet")
+	}
+	if _, _, err := wb.WrapMinified(); err != nil {
+		t.Errorf("WrapMinified : Failed to minify, error %s", err)
+	}
+}
+
+func TestWrapCustomElements(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JSClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := []interface{}{engine.JSParam{Field: "bgColor", Value: "'red'"}, engine.JSParam{Field: "title", Value: "''"}}
+	if _, errs := wb.Inject(wbzr.WooblyJS, "My_Creation", params); len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+	wb.Format = wbzr.CustomElements
+
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `customElements.define("woobly-my_creation", element("My_Creation", {
+      "bg-color": "bgColor",
+      "title": "title"
+    }));`
+	if !strings.Contains(bf.String(), expected) {
+		t.Errorf("Wrap : Unexpected library %s", bf.String())
+	}
+	if _, _, err := wb.WrapMinified(); err != nil {
+		t.Errorf("WrapMinified : Failed to minify, error %s", err)
+	}
+
+	if _, errs := wb.Inject(wbzr.WooblyJS, `x"+alert(1)+"`, nil); len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+	bf, err = wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `if (Wb("x\"+alert(1)+\"") && !customElements.get("woobly-x--alert-1---")) customElements.define("woobly-x--alert-1---", element("x\"+alert(1)+\"", {`
+	if !strings.Contains(bf.String(), expected) {
+		t.Errorf("Wrap : Expected the quoted name escaped in %s", bf.String())
+	}
+	if _, _, err := wb.WrapMinified(); err != nil {
+		t.Errorf("WrapMinified : Failed to minify a quoted name, error %s", err)
+	}
+
+	// Names with the same element are rejected, in this format only
+	wb, err = wbzr.New(wbzr.JSClass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a b", "a-b"} {
+		if _, errs := wb.Inject(wbzr.WooblyJS, name, nil); len(errs) > 0 {
+			t.Fatalf("Failed to inject, errors %s", errs)
+		}
+	}
+	if _, err := wb.Wrap(); err != nil {
+		t.Errorf("Wrap : Unexpected error %s", err)
+	}
+	wb.Format = wbzr.CustomElements
+	if _, err := wb.Wrap(); !errors.Is(err, wbzr.ErrElementName) {
+		t.Errorf("Wrap : Expected %s, got %v", wbzr.ErrElementName, err)
+	}
+}
+
+func TestInjectPolicy(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb.Policy = doc.NewPolicy(doc.Strict)
+	sc, errs := wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", "foo", nil)
+	if len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+	if err := sc.IncludeHTMLCSS("<iframe src='https://evil.com'></iframe>", ""); err != nil {
+		t.Fatal(err)
+	}
+	if s := sc.(engine.Sanitizer).Stripped(); len(s) != 1 || s[0].Code != engine.CodeStripped {
+		t.Errorf("IncludeHTMLCSS : Expected the iframe to be stripped, got %v", s)
+	}
+
+	// A bound URL with a scheme the policy does not allow is emptied
+	sc, errs = wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", "link",
+		[]interface{}{engine.JSParam{Field: "href", Value: "''"}})
+	if len(errs) > 0 {
+		t.Fatalf("Failed to inject, errors %s", errs)
+	}
+	if err := sc.IncludeHTMLCSS(`<a href="{{href}}">x</a>`, ""); err != nil {
+		t.Fatal(err)
+	}
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, _ := json.Marshal(map[string]interface{}{"wooble.js": map[string]string{"src": bf.String()}})
+	script := splitPage + `
+var hrefs = [' JaVa\tscript:steal()', 'data:text/html,x', '/page', 'https://wooble.io', 'MAILTO:a@b.c'];
+hrefs.reduce(function (p, href) {
+  return p.then(function () {
+    return Wb('link').init('#t', {href: href}).then(function (cs) {
+      console.log(JSON.stringify(target.shadowRoot.children[0].attrs.href));
+    });
+  });
+}, Promise.resolve());`
+	out, err := exec.Command(node, "-e", script, string(args)).CombinedOutput()
+	expected := "\"\"\n\"\"\n\"/page\"\n\"https://wooble.io\"\n\"MAILTO:a@b.c\"\n"
+	if err != nil || string(out) != expected {
+		t.Errorf("Expected the bound URLs\n%s\ngot\n%s, error %v", expected, out, err)
+	}
+}
+
+func TestSchemasAndDeclarations(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; };"
+	max := 5.0
+	wb.Inject(valid, "my-creation", []interface{}{
+		engine.JSParam{Field: "stars", Type: engine.ParamNumber, Default: 3, Rules: &engine.ParamRules{Max: &max}},
+		engine.JSParam{Field: "align", Type: engine.ParamEnum, Required: true, Rules: &engine.ParamRules{Enum: []interface{}{"left", "right"}}},
+		engine.JSParam{Field: "legacy", Value: "'raw'"},
+	})
+	wb.Inject(valid, "basic", nil)
+
+	b, err := json.Marshal(wb.Schemas()["my-creation"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"my-creation","type":"object","properties":{` +
+		`"align":{"enum":["left","right"]},"legacy":{},"stars":{"type":"number","maximum":5,"default":3}},"required":["align"],"additionalProperties":false}`
+	if string(b) != expected {
+		t.Errorf("Schemas : Got %s, expected %s", b, expected)
+	}
+
+	wb.Format = wbzr.ESModule
+	decl := wb.Declarations().String()
+	for _, expected := range []string{
+		`type Name = "basic" | "my-creation";`,
+		`"basic": {
+      init(target: string | Element, params?: {}): Promise<Creation[]> | undefined;
+    };`,
+		`      init(target: string | Element, params: {
+        /** @default 3 */
+        "stars"?: number;
+        "align": "left" | "right";
+        "legacy"?: any;
+      }): Promise<Creation[]> | undefined;`,
+		"declare function Wb<N extends Wb.Name>(id: N): Wb.Woobles[N];",
+		`export declare var myCreation: Wb.Woobles["my-creation"];`,
+	} {
+		if !strings.Contains(decl, expected) {
+			t.Errorf("Declarations : Expected %s in\n%s", expected, decl)
+		}
+	}
+
+	// The output does not depend on the order of injection
+	wb.Scripts[0], wb.Scripts[1] = wb.Scripts[1], wb.Scripts[0]
+	if again := wb.Declarations().String(); again != decl {
+		t.Errorf("Declarations : Not deterministic\n%s\nthen\n%s", decl, again)
+	}
+
+	// A field is a string, even if it is not an identifier
+	odd, _ := engine.NewJS("odd", valid, []engine.JSParam{{Field: "data-x", Value: "1"}})
+	wb.Scripts = append(wb.Scripts, odd)
+	if decl := wb.Declarations().String(); !strings.Contains(decl, `        "data-x"?: any;`) {
+		t.Errorf("Declarations : Expected the field quoted in\n%s", decl)
+	}
+}
+
+func TestSecureWithLicense(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", "obj", nil)
+
+	key, _ := license.GenerateKey(license.EdDSA, "2024")
+	expired, _ := key.Sign(&license.License{Domains: []string{"example.com"}, Expiry: time.Now().Add(-time.Hour)})
+	if err := wb.SecureWithLicense(expired, key); err != license.ErrExpired {
+		t.Errorf("Expected %s for an expired license, got %v", license.ErrExpired, err)
+	}
+
+	token, _ := key.Sign(&license.License{Customer: "cus_42", Domains: []string{"example.com"}, Expiry: time.Now().Add(time.Hour)})
+	if err := wb.SecureWithLicense(token, key); err != nil {
+		t.Fatalf("Failed to secure with a license, error %s", err)
+	}
+	if wb.License.Key.CanSign() {
+		t.Error("The library should embed the public key only")
+	}
+
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatalf("Failed to wrap, error %s", err)
+	}
+	pub := base64.RawURLEncoding.EncodeToString(key.VerifyingKey())
+	for _, expected := range []string{token, pub, "function ed25519("} {
+		if !strings.Contains(bf.String(), expected) {
+			t.Errorf("Expected %s in\n%s", expected, bf)
+		}
+	}
+	if _, _, err := wb.WrapMinified(); err != nil {
+		t.Errorf("Failed to wrap minified, error %s", err)
+	}
+	if !strings.Contains(wb.Declarations().String(), "Wb.Woobles[N] | undefined;") {
+		t.Error("Wb of a licensed library may return undefined")
+	}
+}
+
+// TestLicenseVectors runs the verification of the runtime on the vectors of
+// the license package, with node.
+func TestLicenseVectors(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	src, err := ioutil.ReadFile("apis/license.js")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vectors, err := ioutil.ReadFile("license/testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := regexp.MustCompile(`\{\{[^}]*\}\}`).ReplaceAllString(string(src), "") + `
+var vs = ` + string(vectors) + `;
+vs.forEach(function (v) {
+  var got = license(v.token, v.key, v.alg, v.host, v.now);
+  if (got != v.error) console.log(v.name + ' : Expected "' + v.error + '", got "' + got + '"');
+});`
+
+	out, err := exec.Command(node, "-e", script).CombinedOutput()
+	if err != nil {
+		t.Fatalf("node failed, error %s\n%s", err, out)
+	}
+	if len(out) > 0 {
+		t.Errorf("Runtime verification differs from the vectors\n%s", out)
+	}
+}
+
+func TestSecureDomains(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", "obj", nil)
+
+	domains := []string{"example.com", "*.customer.io", "Bücher.de", "staging.example.com:8443", "10.1.0.0/16", "192.168.1.7:8080", "[fd00::]/8"}
+	if err := wb.Secure(domains...); err != nil {
+		t.Fatalf("Failed to secure, error %s", err)
+	}
+	for _, invalid := range []string{
+		"https://example.com", "example.com/path", "example.com:0", "example.com:http", "*.com", "a.*.com",
+		"exa mple.com", "a..com", "-a.com", "1.2.3", "10.0.0.0/33", "::1", "[::1", "[::ffff:1.2.3.4]", "*",
+	} {
+		if err := wb.Secure(invalid); !errors.Is(err, wbzr.ErrDomainPattern) {
+			t.Errorf("Expected %s for %q, got %v", wbzr.ErrDomainPattern, invalid, err)
+		}
+	}
+	if len(wb.DomainsSec) != len(domains) {
+		t.Errorf("Invalid domains should not change the secured domains, got %v", wb.DomainsSec)
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	for _, allowLocalhost := range []bool{false, true} {
+		wb.AllowLocalhost = allowLocalhost
+		bf, err := wb.Wrap()
+		if err != nil {
+			t.Fatalf("Failed to wrap, error %s", err)
+		}
+		cases := []struct {
+			url     string
+			allowed bool
+		}{
+			{"https://example.com/page", true},
+			{"http://example.com:3000/", true},
+			{"https://www.example.com/", false},
+			{"https://notexample.com/", false},
+			{"https://a.customer.io/", true},
+			{"https://a.b.customer.io/", true},
+			{"https://customer.io/", false},
+			{"https://acustomer.io/", false},
+			{"https://xn--bcher-kva.de/", true},
+			{"https://staging.example.com:8443/", true},
+			{"https://staging.example.com/", false},
+			{"http://10.1.255.3/", true},
+			{"http://10.2.0.1/", false},
+			{"http://192.168.1.7:8080/", true},
+			{"http://192.168.1.7/", false},
+			{"http://[fd12:3456::1]/", true},
+			{"http://[fe80::1]/", false},
+			{"http://localhost:8080/", allowLocalhost},
+			{"http://app.localhost/", allowLocalhost},
+			{"http://127.0.0.1/", allowLocalhost},
+			{"http://[::1]:3000/", allowLocalhost},
+		}
+		script := "var window = {};\nconsole.log = function () {};\n" + bf.String()
+		for _, c := range cases {
+			script += "\nwindow.location = new URL(" + strconv.Quote(c.url) + ");\nprocess.stdout.write((Wb('obj') !== undefined) + ' ');"
+		}
+		out, err := exec.Command(node, "-e", script).CombinedOutput()
+		if err != nil {
+			t.Fatalf("node failed, error %s\n%s", err, out)
+		}
+		got := strings.Fields(string(out))
+		if len(got) != len(cases) {
+			t.Fatalf("Unexpected node output %s", out)
+		}
+		for i, c := range cases {
+			if got[i] != strconv.FormatBool(c.allowed) {
+				t.Errorf("%s (localhost allowed %t) : Expected allowed %t", c.url, allowLocalhost, c.allowed)
+			}
+		}
+	}
+}
+
+// splitPage runs a split library in node with a fake document, the script
+// elements appended to the head load the files, or fail if missing
+const splitPage = `
+var vm = require('vm'), files = JSON.parse(process.argv[1]), loaded = [];
+function el(tag) {
+  return {
+    tagName: tag, children: [], attrs: {},
+    setAttribute: function (k, v) { this.attrs[k] = v; },
+    appendChild: function (c) { this.children.push(c); return c; },
+    attachShadow: function () { return this.shadowRoot = el('#shadow'); }
+  };
+}
+var target = el('div');
+var document = {
+  head: el('head'), body: el('body'),
+  createElement: el,
+  createTextNode: function (t) { return { text: t }; },
+  querySelector: function () { return target; },
+  querySelectorAll: function () { return [target]; }
+};
+document.head.attachShadow = function () {};
+document.head.appendChild = function (s) {
+  loaded.push(s.src);
+  setTimeout(function () {
+    var f = files[s.src.replace(/^.*\//, '')];
+    if (f == null || s.integrity != f.integrity) return s.onerror();
+    vm.runInThisContext(f.src);
+    s.onload();
+  });
+};
+global.window = global; global.document = document;
+vm.runInThisContext(files['wooble.js'].src);
+`
+
+func TestWrapSplit(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := "var Woobly = function Woobly() { this.document = document.body.shadowRoot; this.%s = true; };"
+	styled, _ := wb.Inject(fmt.Sprintf(src, "styled"), "styled", []interface{}{engine.JSParam{Field: "n", Type: engine.ParamNumber, Default: 1}})
+	plain, _ := wb.Inject(fmt.Sprintf(src, "plain"), "my plain", nil)
+	if err := styled.IncludeHTMLCSS("<p>hello</p>", "p { color: red; }"); err != nil {
+		t.Fatal(err)
+	}
+	if err := plain.IncludeHTMLCSS("<p>hi</p>", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	files, m, err := wb.WrapSplit("https://cdn.example.com/lib")
+	if err != nil {
+		t.Fatalf("Failed to split, error %s", err)
+	}
+	if len(files) != 3 || len(m.Files) != 3 || m.Loader != wbzr.LoaderFile || len(m.Chunks) != 2 {
+		t.Fatalf("Unexpected manifest %+v", m)
+	}
+	for name, f := range files {
+		sum := sha512.Sum384(f.Bytes())
+		if m.Files[name].Integrity != "sha384-"+base64.StdEncoding.EncodeToString(sum[:]) || m.Files[name].Size != f.Len() {
+			t.Errorf("%s : Unexpected manifest entry %+v", name, m.Files[name])
+		}
+	}
+	if !regexp.MustCompile(`^my-plain\.[0-9a-f]{8}\.js$`).MatchString(m.Chunks["my plain"]) {
+		t.Errorf("Unexpected chunk file %s", m.Chunks["my plain"])
+	}
+	loader := files[wbzr.LoaderFile].String()
+	if strings.Contains(loader, "this.plain") || strings.Contains(loader, "p{color:red}") || !strings.Contains(loader, `Wb.base = "https://cdn.example.com/lib/"`) {
+		t.Errorf("The loader should not embed the creations\n%s", loader)
+	}
+	if !strings.Contains(files[m.Chunks["styled"]].String(), "p{color:red}") {
+		t.Errorf("The chunk should embed the style sheet\n%s", files[m.Chunks["styled"]])
+	}
+
+	// The build is deterministic
+	again, _, _ := wb.WrapSplit("https://cdn.example.com/lib")
+	for name, f := range files {
+		if again[name] == nil || again[name].String() != f.String() {
+			t.Errorf("%s : Not deterministic", name)
+		}
+	}
+	if _, _, err := wb.WrapSplitMinified(""); err != nil {
+		t.Errorf("Failed to split minified, error %s", err)
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	page := make(map[string]interface{})
+	for name, f := range files {
+		page[name] = map[string]string{"src": f.String(), "integrity": m.Files[name].Integrity}
+	}
+	// The second chunk is missing, its init fails and is retried
+	delete(page, m.Chunks["my plain"])
+	args, _ := json.Marshal(page)
+	script := splitPage + `
+var w = Wb('styled');
+Promise.all([w.init('#t', { n: 2 }), w.init('#t')]).then(function (cs) {
+  console.log(cs[0][0].styled, cs[0][0].constructor.__css, loaded.length);
+  return Wb('my plain').init('#t');
+}).then(function () {
+  console.log('loaded');
+}, function (e) {
+  console.log(e.message);
+  return Wb('my plain').init('#t').catch(function () { console.log(loaded.length); });
+});`
+	out, err := exec.Command(node, "-e", script, string(args)).CombinedOutput()
+	if err != nil {
+		t.Fatalf("node failed, error %s\n%s", err, out)
+	}
+	expected := "true p{color:red} 1\nWooble error : chunk " + m.Chunks["my plain"] + " of my plain not loaded\n3\n"
+	if string(out) != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, out)
+	}
+}
+
+func TestWrapHelpers(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	helpers := `var _createClass = function () { function defineProperties(target, props) { for (var i = 0; i < props.length; i++) { var descriptor = props[i]; descriptor.enumerable = descriptor.enumerable || false; descriptor.configurable = true; if ("value" in descriptor) descriptor.writable = true; Object.defineProperty(target, descriptor.key, descriptor); } } return function (Constructor, protoProps, staticProps) { if (protoProps) defineProperties(Constructor.prototype, protoProps); if (staticProps) defineProperties(Constructor, staticProps); return Constructor; }; }();
+
+function _classCallCheck(instance, Constructor) { if (!(instance instanceof Constructor)) { throw new TypeError("Cannot call a class as a function"); } }
+`
+	class := `var Woobly = function () {
+  function Woobly(params) {
+    _classCallCheck(this, Woobly);
+    this.document = document.body.shadowRoot;
+  }
+  _createClass(Woobly, [{ key: "name", get: function get() { return "%s"; } }]);
+  return Woobly;
+}();`
+	for _, name := range []string{"first", "second"} {
+		if _, errs := wb.Inject(helpers+fmt.Sprintf(class, name), name, nil); len(errs) > 0 {
+			t.Fatalf("Inject : Unexpected errors %v", errs)
+		}
+	}
+	wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", "plain", nil)
+
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lib := bf.String()
+	if strings.Count(lib, "var _createClass =") != 1 || strings.Count(lib, "function _classCallCheck(") != 1 || strings.Contains(lib, "_slicedToArray") {
+		t.Errorf("Wrap : Expected each used helper once\n%s", lib)
+	}
+
+	files, m, err := wb.WrapSplit("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(files[wbzr.LoaderFile].String(), "_createClass") || strings.Contains(files[m.Chunks["plain"]].String(), "_createClass") ||
+		!strings.Contains(files[m.Chunks["first"]].String(), "var _createClass =") {
+		t.Error("WrapSplit : Expected the helpers in the chunks using them only")
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	args, _ := json.Marshal(map[string]interface{}{"wooble.js": map[string]string{"src": lib}})
+	script := splitPage + `
+Promise.all([Wb('first').init('#t'), Wb('second').init('#t')]).then(function (cs) {
+  console.log(cs[0][0].name, cs[1][0].name);
+});`
+	out, err := exec.Command(node, "-e", script, string(args)).CombinedOutput()
+	if err != nil || string(out) != "first second\n" {
+		t.Errorf("The creations should run with the shared helpers, error %v\n%s", err, out)
+	}
+}
+
+func TestPolyfill(t *testing.T) {
+	wb, err := wbzr.New(wbzr.JS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wb.Inject("var Woobly = function Woobly() { this.document = document.body.shadowRoot; };", "obj", nil)
+
+	bf, err := wb.Wrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(bf.String(), wbzr.DefaultPolyfillURL) {
+		t.Error("Wrap : Expected the default polyfill")
+	}
+	for _, p := range []wbzr.Polyfill{{Integrity: "md5-abc"}, {Integrity: "sha384-abc", Disabled: true}} {
+		wb.Polyfill = p
+		if _, err := wb.Wrap(); !errors.Is(err, wbzr.ErrPolyfill) {
+			t.Errorf("Wrap : Expected %s for %+v, got %v", wbzr.ErrPolyfill, p, err)
+		}
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not found")
+	}
+	sri := "sha384-" + base64.StdEncoding.EncodeToString(make([]byte, 48))
+	for _, test := range []struct {
+		polyfill wbzr.Polyfill
+		expected string
+	}{
+		// The polyfill is missing, then served
+		{wbzr.Polyfill{URL: "https://example.com/wc.js", Integrity: sri}, "Wooble error : polyfill https://example.com/wc.js not loaded\n1\n"},
+		{wbzr.Polyfill{Inline: []byte("window.polyfilled = true // no new line")}, "1\n"},
+		{wbzr.Polyfill{Disabled: true}, "Wooble error : Shadow DOM not supported, the polyfill is disabled\n"},
+	} {
+		wb.Polyfill = test.polyfill
+		bf, err := wb.Wrap()
+		if err != nil {
+			t.Fatalf("Wrap : Failed with %+v, error %s", test.polyfill, err)
+		}
+		args, _ := json.Marshal(map[string]interface{}{"wooble.js": map[string]string{"src": bf.String()}})
+		script := splitPage + `
+delete document.head.attachShadow;
+function init() {
+  return Wb('obj').init('#t').then(function (cs) {
+    console.log(cs.length);
+  });
+}
+init().catch(function (e) {
+  console.log(e.message);
+  files['wc.js'] = { src: 'window.polyfilled = true;', integrity: '` + sri + `' };
+  return init();
+}).catch(function () {});`
+		out, err := exec.Command(node, "-e", script, string(args)).CombinedOutput()
+		if err != nil || string(out) != test.expected {
+			t.Errorf("%+v : Expected\n%s\ngot\n%s, error %v", test.polyfill, test.expected, out, err)
+		}
+	}
 }